@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	"github.com/blakyaks/yaml-docs/pkg/document"
+)
+
+// applyGitSinceVersions records the version in which each value was introduced, based on the git history of the file
+func applyGitSinceVersions(info *config.DocumentationInfo, configPath string) {
+	if fileInfo, err := os.Stat(configPath); err != nil || fileInfo.IsDir() {
+		log.Warnf("Version history is only available for single config files, skipping %s", configPath)
+		return
+	}
+
+	revisions, err := document.LoadConfigRevisions(configPath, viper.GetString("git-revisions"))
+	if err != nil {
+		log.Warnf("Error loading the git history of %s: %s", configPath, err)
+		return
+	}
+
+	history, err := document.BuildConfigHistory(revisions)
+	if err != nil {
+		log.Warnf("Error building the configuration history of %s: %s", configPath, err)
+		return
+	}
+
+	info.SinceVersions = document.GetSinceVersions(history)
+}
+
+func configChangelog(cmd *cobra.Command, args []string) {
+	initializeCli()
+
+	format, _ := cmd.Flags().GetString("format")
+	if format != markdownOutputFormat && format != jsonOutputFormat {
+		log.Errorf("Invalid output format provided %s, must be one of (%s, %s)", format, markdownOutputFormat, jsonOutputFormat)
+		os.Exit(1)
+	}
+
+	revisions, err := document.LoadConfigRevisions(args[0], viper.GetString("git-revisions"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	history, err := document.BuildConfigHistory(revisions)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if format == jsonOutputFormat {
+		err = writeJson(os.Stdout, history)
+	} else {
+		_, err = fmt.Fprint(os.Stdout, document.ConfigChangelogMarkdown(history))
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/blakyaks/yaml-docs/pkg/config"
 	"github.com/blakyaks/yaml-docs/pkg/document"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -35,25 +36,28 @@ func initializeCli() {
 	log.SetLevel(logLevel)
 }
 
+func checkConfigSourceFlags(cmd *cobra.Command) {
+	configSearchRoot, _ := cmd.Flags().GetString("config-search-root")
+	configFiles, _ := cmd.Flags().GetStringSlice("config-file")
+
+	if configSearchRoot != "" && len(configFiles) > 0 {
+		log.Error("config-search-root and values-file are mutually exclusive.")
+		os.Exit(1)
+	}
+
+	if configSearchRoot == "" && len(configFiles) == 0 {
+		log.Error("One of config-search-root or values-file must be provided.")
+		os.Exit(1)
+	}
+}
+
 func newYamlDocsCommand(run func(cmd *cobra.Command, args []string)) (*cobra.Command, error) {
 	command := &cobra.Command{
 		Use:     "yaml-docs",
 		Short:   "yaml-docs automatically generates markdown documentation from YAML configuration files",
 		Version: version,
 		Run: func(cmd *cobra.Command, args []string) {
-			configSearchRoot, _ := cmd.Flags().GetString("config-search-root")
-			configFiles, _ := cmd.Flags().GetStringSlice("config-file")
-
-			if configSearchRoot != "" && len(configFiles) > 0 {
-				log.Error("config-search-root and values-file are mutually exclusive.")
-				os.Exit(1)
-			}
-
-			if configSearchRoot == "" && len(configFiles) == 0 {
-				log.Error("One of config-search-root or values-file must be provided.")
-				os.Exit(1)
-			}
-
+			checkConfigSourceFlags(cmd)
 			run(cmd, args)
 		},
 	}
@@ -65,8 +69,11 @@ func newYamlDocsCommand(run func(cmd *cobra.Command, args []string)) (*cobra.Com
 	command.PersistentFlags().Bool("skip-toc", false, "if set, a table of contents will not be created in the default README template")
 	command.PersistentFlags().Bool("no-section-page-breaks", false, "if set, page breaks will not be applied for each section in the default README template")
 	command.PersistentFlags().Bool("disable-section-inheritance", false, "if set, sections will not be inherited during document processing")
+	command.PersistentFlags().Bool("since-from-git", false, "if set, the version in which each value was introduced is taken from the git history of single config files and made available to templates")
+	command.PersistentFlags().String("git-revisions", document.TagRevisions, fmt.Sprintf("git revisions used to build the configuration history (\"%s\" or \"%s\")", document.TagRevisions, document.CommitRevisions))
 	command.PersistentFlags().BoolP("documentation-strict-mode", "x", false, "Fail the generation of docs if there are undocumented values")
 	command.PersistentFlags().BoolP("dry-run", "d", false, "don't actually render any markdown files just print to stdout passed")
+	command.PersistentFlags().String("current-version", "", "the current version of the configuration, in strict mode values with an @removedIn version at or below it fail the generation of docs")
 	command.PersistentFlags().StringP("config-search-root", "c", "", "directory to search recursively for configuration files, mutually exclusive with values-file")
 	command.PersistentFlags().StringP("header-file", "H", ".document-header.md", "The external header content file that will be prepended to the standard template output. If the file does not exist templates will render without a custom header.")
 	command.PersistentFlags().StringP("ignore-file", "i", ".yamldocsignore", "The filename to use as an ignore file to exclude configuration directories and files")
@@ -77,6 +84,26 @@ func newYamlDocsCommand(run func(cmd *cobra.Command, args []string)) (*cobra.Com
 	command.PersistentFlags().StringSliceP("config-file", "f", []string{}, "yaml configuration file to be parsed into values table. Can be specified multiple times, mutually exclusive with config-search-root")
 	command.PersistentFlags().StringSliceP("documentation-strict-ignore-absent-regex", "z", []string{".*service\\.type", ".*image\\.repository", ".*image\\.tag"}, "A comma separate values which are allowed not to be documented in strict mode")
 	command.PersistentFlags().StringSliceP("documentation-strict-ignore-absent", "y", []string{"service.type", "image.repository", "image.tag"}, "A comma separate values which are allowed not to be documented in strict mode")
+	command.PersistentFlags().StringSlice("sensitive-key-regex", []string{}, "A comma separated list of key path regexes whose defaults are masked in every output, in addition to values annotated with @sensitive, e.g. (?i).*password.*")
+	command.PersistentFlags().StringSlice("tag-display", []string{}, fmt.Sprintf("display of defaults with a custom YAML tag in the form <tag>=<mode>, where mode is one of (%s, %s, %s), e.g. !secret=%s", document.RawTagDisplay, document.TagTagDisplay, document.MaskTagDisplay, document.MaskTagDisplay))
+	command.PersistentFlags().StringSlice("include-path", []string{}, "A comma separated list of key path globs, where * matches any characters, to document along with the keys below them; every key is documented when none is set")
+	command.PersistentFlags().StringSlice("include-path-regex", []string{}, "A comma separated list of key path regexes to document, in addition to include-path")
+	command.PersistentFlags().StringSlice("exclude-path", []string{}, "A comma separated list of key path globs, where * matches any characters, to leave out of the documentation along with the keys below them")
+	command.PersistentFlags().StringSlice("exclude-path-regex", []string{}, "A comma separated list of key path regexes to leave out of the documentation, in addition to exclude-path")
+	command.PersistentFlags().Bool("resolve-includes", false, "if set, scalars tagged !include are replaced by the contents of the referenced YAML file, relative to the including file")
+	command.PersistentFlags().String("long-default-mode", document.InlineLongDefaults, fmt.Sprintf("how defaults longer than long-default-threshold are rendered in the default README template (\"%s\", \"%s\" or \"%s\")", document.InlineLongDefaults, document.DetailsLongDefaults, document.AppendixLongDefaults))
+	command.PersistentFlags().Int("long-default-threshold", 80, "length above which a rendered default is considered long, see long-default-mode")
+	command.PersistentFlags().String("values-layout", document.FlatValuesLayout, fmt.Sprintf("layout of the values in the default README template, a single table of key paths or a heading per parent key (\"%s\" or \"%s\")", document.FlatValuesLayout, document.NestedValuesLayout))
+	command.PersistentFlags().String("key-notation", config.DefaultKeyNotation, fmt.Sprintf("notation of value key paths in tables, explicit comment keys and strict mode allow lists, one of (%s)", strings.Join(config.KeyNotations, ", ")))
+	command.PersistentFlags().String("env-prefix", "", "prefix of the environment variable names that override each value, as set with viper.SetEnvPrefix")
+	command.PersistentFlags().String("env-separator", "_", "separator between the prefix and key path segments of environment variable names")
+	command.PersistentFlags().String("env-convention", document.ViperEnvConvention, fmt.Sprintf("convention used to map key paths to environment variable names (\"%s\" or \"%s\")", document.ViperEnvConvention, document.SpringEnvConvention))
+	command.PersistentFlags().String("env-file", "", "if set, an example environment file with the default of each documented value is written to this path, e.g. .env.example")
+	command.PersistentFlags().StringSlice("extra-columns", []string{}, fmt.Sprintf("additional columns in the values tables of the default README template, any of (%s, %s)", document.EnvColumn, document.SetColumn))
+	command.PersistentFlags().String("schema-file", "", "JSON schema, relative to each configuration directory, whose descriptions are used for values without comments, e.g. values.schema.json")
+	command.PersistentFlags().String("schema-precedence", document.CommentsSchemaPrecedence, fmt.Sprintf("source used when comments and the JSON schema both describe a value (\"%s\" or \"%s\")", document.CommentsSchemaPrecedence, document.SchemaSchemaPrecedence))
+	command.PersistentFlags().String("subchart-values", config.AllSubchartValues, fmt.Sprintf("values of the subcharts in the charts directory of a Helm chart documented under the key of their dependency, one of (%s)", strings.Join(config.SubchartValuesModes, ", ")))
+	command.PersistentFlags().String("input-mode", config.ValuesInputMode, fmt.Sprintf("kind of YAML files that are documented, one of (%s)", strings.Join(config.InputModes, ", ")))
 	command.PersistentFlags().StringSliceP("template-files", "t", []string{"README.md.gotmpl"}, "gotemplate file paths relative to each configuration directory from which documentation will be generated")
 
 	command.SetVersionTemplate(`{{printf "%s" .Version}}`)
@@ -88,3 +115,66 @@ func newYamlDocsCommand(run func(cmd *cobra.Command, args []string)) (*cobra.Com
 
 	return command, err
 }
+
+func newGetCommand(run func(cmd *cobra.Command, args []string)) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "get <path>",
+		Short: "Print the documentation for a single value key path",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			checkConfigSourceFlags(cmd)
+			run(cmd, args)
+		},
+	}
+
+	command.Flags().String("format", textOutputFormat, fmt.Sprintf("output format, one of (%s, %s)", textOutputFormat, jsonOutputFormat))
+
+	return command
+}
+
+func newListCommand(run func(cmd *cobra.Command, args []string)) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "list",
+		Short: "Print the documented values, optionally filtered by section, required or deprecated flags",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			checkConfigSourceFlags(cmd)
+			run(cmd, args)
+		},
+	}
+
+	command.Flags().String("format", textOutputFormat, fmt.Sprintf("output format, one of (%s, %s)", textOutputFormat, jsonOutputFormat))
+	command.Flags().String("section", "", "only list values in this section")
+	command.Flags().Bool("required", false, "only list values marked as @required")
+	command.Flags().Bool("deprecated", false, "only list values marked as @deprecated")
+
+	return command
+}
+
+func newDiffCommand(run func(cmd *cobra.Command, args []string)) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "diff <old> <new>",
+		Short: "Report the configuration changes between two versions of a config file",
+		Long: "Report the configuration changes between two versions of a config file. Each argument may be a path on disk " +
+			"or a git revision and path in the form <ref>:<path>, e.g. v1.2.0:values.yaml.",
+		Args: cobra.ExactArgs(2),
+		Run:  run,
+	}
+
+	command.Flags().String("format", markdownOutputFormat, fmt.Sprintf("output format, one of (%s, %s)", markdownOutputFormat, jsonOutputFormat))
+
+	return command
+}
+
+func newChangelogCommand(run func(cmd *cobra.Command, args []string)) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "changelog <config-file>",
+		Short: "Generate a changelog of configuration changes from the git history of a config file",
+		Args:  cobra.ExactArgs(1),
+		Run:   run,
+	}
+
+	command.Flags().String("format", markdownOutputFormat, fmt.Sprintf("output format, one of (%s, %s)", markdownOutputFormat, jsonOutputFormat))
+
+	return command
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	"github.com/blakyaks/yaml-docs/pkg/document"
+	"github.com/blakyaks/yaml-docs/pkg/util"
+)
+
+// loadConfigRevision parses a config file from disk, or from git when the argument is of the form <ref>:<path> and no
+// such file exists on disk. Git revisions are parsed from a copy of the directory of the file at that revision, so that
+// both sides of a diff are parsed the same way, along with their chart, subcharts and schema.
+func loadConfigRevision(arg string, documentationParsingConfig config.DocumentationParsingConfig) (config.DocumentationInfo, error) {
+	if _, err := os.Stat(arg); err == nil {
+		return config.ParseConfigPath(util.GetAbsolutePath(arg), documentationParsingConfig)
+	}
+
+	ref, path, found := strings.Cut(arg, ":")
+	if !found || ref == "" || path == "" {
+		return config.DocumentationInfo{}, fmt.Errorf("%s is neither an existing file nor a <ref>:<path> git revision", arg)
+	}
+
+	directory, err := os.MkdirTemp("", "yaml-docs-diff-")
+	if err != nil {
+		return config.DocumentationInfo{}, err
+	}
+	defer os.RemoveAll(directory)
+
+	revisionPath, err := util.ExtractGitFileDirectory(ref, path, directory)
+	if err != nil {
+		return config.DocumentationInfo{}, err
+	}
+
+	info, err := config.ParseConfigPath(revisionPath, documentationParsingConfig)
+	if err != nil {
+		return config.DocumentationInfo{}, err
+	}
+	info.ConfigPath = arg
+
+	return info, nil
+}
+
+func diffValues(cmd *cobra.Command, args []string) {
+	initializeCli()
+
+	format, _ := cmd.Flags().GetString("format")
+	if format != markdownOutputFormat && format != jsonOutputFormat {
+		log.Errorf("Invalid output format provided %s, must be one of (%s, %s)", format, markdownOutputFormat, jsonOutputFormat)
+		os.Exit(1)
+	}
+
+	documentationParsingConfig, err := getDocumentationParsingConfigFromArgs()
+	if err != nil {
+		log.Fatalf("Error parsing the linting config: %s", err)
+	}
+	documentationParsingConfig.StrictMode = false
+
+	oldInfo, err := loadConfigRevision(args[0], documentationParsingConfig)
+	if err != nil {
+		log.Fatalf("Failed to load %s: %s", args[0], err)
+	}
+
+	newInfo, err := loadConfigRevision(args[1], documentationParsingConfig)
+	if err != nil {
+		log.Fatalf("Failed to load %s: %s", args[1], err)
+	}
+
+	diff, err := document.DiffValues(oldInfo, newInfo)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if format == jsonOutputFormat {
+		err = writeJson(os.Stdout, diff)
+	} else {
+		_, err = fmt.Fprint(os.Stdout, diff.Markdown())
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
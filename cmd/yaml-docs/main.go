@@ -2,11 +2,13 @@ package main
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"reflect"
 	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 
@@ -25,6 +27,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	command.AddCommand(newGetCommand(getValue))
+	command.AddCommand(newListCommand(listValues))
+	command.AddCommand(newDiffCommand(diffValues))
+	command.AddCommand(newChangelogCommand(configChangelog))
+
 	if err := command.Execute(); err != nil {
 		log.Errorf("Failed to start the CLI: %s", err)
 		os.Exit(1)
@@ -67,6 +74,18 @@ func parallelProcessIterable(iterable interface{}, parallelism int, visitFn func
 }
 
 func getDocumentationParsingConfigFromArgs() (config.DocumentationParsingConfig, error) {
+	keyNotation := viper.GetString("key-notation")
+	if keyNotation == "" {
+		keyNotation = config.DefaultKeyNotation
+	}
+	if !config.IsKeyNotation(keyNotation) {
+		return config.DocumentationParsingConfig{}, fmt.Errorf("invalid key notation %s, must be one of (%s)", keyNotation, strings.Join(config.KeyNotations, ", "))
+	}
+
+	if inputMode := viper.GetString("input-mode"); inputMode != "" && !slices.Contains(config.InputModes, inputMode) {
+		return config.DocumentationParsingConfig{}, fmt.Errorf("invalid input mode %s, must be one of (%s)", inputMode, strings.Join(config.InputModes, ", "))
+	}
+
 	var regexps []*regexp.Regexp
 	regexpStrings := viper.GetStringSlice("documentation-strict-ignore-absent-regex")
 	for _, item := range regexpStrings {
@@ -80,6 +99,7 @@ func getDocumentationParsingConfigFromArgs() (config.DocumentationParsingConfig,
 		StrictMode:                 viper.GetBool("documentation-strict-mode"),
 		AllowedMissingValuePaths:   viper.GetStringSlice("documentation-strict-ignore-absent"),
 		AllowedMissingValueRegexps: regexps,
+		KeyNotation:                keyNotation,
 	}, nil
 }
 
@@ -125,6 +145,15 @@ func processConfigPaths(configPaths []string, parallelism int) (map[string]confi
 		documentationInfoByConfigPathMu.Unlock()
 	})
 
+	// The history is built serially, as listing the values of each revision tracks auto sections in package state
+	if viper.GetBool("since-from-git") {
+		for _, configPath := range slices.Sorted(maps.Keys(documentationInfoByConfigPath)) {
+			info := documentationInfoByConfigPath[configPath]
+			applyGitSinceVersions(&info, configPath)
+			documentationInfoByConfigPath[configPath] = info
+		}
+	}
+
 	return documentationInfoByConfigPath, nil
 }
 
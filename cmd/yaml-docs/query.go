@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	"github.com/blakyaks/yaml-docs/pkg/document"
+)
+
+const (
+	textOutputFormat     = "text"
+	jsonOutputFormat     = "json"
+	markdownOutputFormat = "markdown"
+)
+
+// loadDocumentationInfo parses the configured config files or search root into a single combined model
+func loadDocumentationInfo() config.DocumentationInfo {
+	configSearchRoot := viper.GetString("config-search-root")
+	configFiles := viper.GetStringSlice("config-file")
+
+	var info map[string]config.DocumentationInfo
+	var err error
+
+	if configSearchRoot != "" {
+		info, err = processConfigPaths([]string{configSearchRoot}, runtime.NumCPU()*2)
+	} else {
+		info, err = processConfigPaths(configFiles, runtime.NumCPU()*2)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(info) == 0 {
+		log.Error("No YAML files were found.")
+		os.Exit(1)
+	}
+
+	return config.CombineDocumentationInfo(info)
+}
+
+func getOutputFormat(cmd *cobra.Command) string {
+	format, _ := cmd.Flags().GetString("format")
+	if format != textOutputFormat && format != jsonOutputFormat {
+		log.Errorf("Invalid output format provided %s, must be one of (%s, %s)", format, textOutputFormat, jsonOutputFormat)
+		os.Exit(1)
+	}
+
+	return format
+}
+
+func writeJson(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func writeValueText(w io.Writer, value document.ValueInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Key:\t%s\n", value.Key)
+	fmt.Fprintf(tw, "Type:\t%s\n", value.Type)
+	fmt.Fprintf(tw, "Default:\t%s\n", value.Default)
+	fmt.Fprintf(tw, "Section:\t%s\n", value.Section)
+	fmt.Fprintf(tw, "Required:\t%t\n", value.Required)
+	fmt.Fprintf(tw, "Deprecated:\t%t\n", value.Deprecated)
+	fmt.Fprintf(tw, "Description:\t%s\n", value.Description)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if value.Example != "" {
+		_, err := fmt.Fprintf(w, "Example:\n  %s\n", strings.ReplaceAll(strings.TrimSpace(value.Example), "\n", "\n  "))
+		return err
+	}
+
+	return nil
+}
+
+func writeValuesText(w io.Writer, values []document.ValueInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tTYPE\tDEFAULT\tSECTION\tDESCRIPTION")
+	for _, value := range values {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", value.Key, value.Type, value.Default, value.Section, strings.ReplaceAll(value.Description, "\n", " "))
+	}
+
+	return tw.Flush()
+}
+
+func getValue(cmd *cobra.Command, args []string) {
+	initializeCli()
+	format := getOutputFormat(cmd)
+	info := loadDocumentationInfo()
+
+	value, found, err := document.GetValue(info, args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if !found {
+		log.Errorf("No documented value found for key %s", args[0])
+		os.Exit(1)
+	}
+
+	if format == jsonOutputFormat {
+		err = writeJson(os.Stdout, value)
+	} else {
+		err = writeValueText(os.Stdout, value)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func listValues(cmd *cobra.Command, _ []string) {
+	initializeCli()
+	format := getOutputFormat(cmd)
+	info := loadDocumentationInfo()
+
+	section, _ := cmd.Flags().GetString("section")
+	required, _ := cmd.Flags().GetBool("required")
+	deprecated, _ := cmd.Flags().GetBool("deprecated")
+
+	values, err := document.ListValues(info, document.ValueFilter{
+		Section:    section,
+		Required:   required,
+		Deprecated: deprecated,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if format == jsonOutputFormat {
+		err = writeJson(os.Stdout, values)
+	} else {
+		err = writeValuesText(os.Stdout, values)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
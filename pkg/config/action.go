@@ -0,0 +1,215 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// actionDocument is a GitHub action metadata file, or a reusable workflow, with the entries it documents
+type actionDocument struct {
+	name     string
+	metadata DocumentMetadata
+	inputs   *yaml.Node
+	outputs  *yaml.Node
+	secrets  *yaml.Node
+}
+
+// parseActionFiles documents the inputs and outputs of GitHub action metadata files, and the inputs, outputs and
+// secrets of reusable workflows. Their description fields are used unless the entry is described by a comment, and
+// keys are prefixed with the name of the action or workflow when there is more than one of them.
+func parseActionFiles(files []string, keyNotation string) (*yaml.Node, map[string]ValueDescription, DocumentMetadata, error) {
+	var metadata DocumentMetadata
+
+	actions := make([]actionDocument, 0)
+	for _, file := range files {
+		document, err := parseConfigFile(file)
+		if err != nil {
+			return nil, nil, metadata, &ParseError{ConfigPath: file, Message: fmt.Sprintf("Error parsing action: %s", err)}
+		}
+
+		if action, ok := readActionDocument(file, &document); ok {
+			actions = append(actions, action)
+		} else {
+			log.Debugf("Skipping %s, which is neither an action nor a reusable workflow", file)
+		}
+	}
+
+	// Keys described by keyed comments, such as "# inputs.token -- ...", take precedence over the description fields
+	keyedDescriptions, err := parseValueDescriptions(files, nil, DocumentationParsingConfig{})
+	if err != nil {
+		return nil, nil, metadata, err
+	}
+
+	descriptions := make(map[string]ValueDescription)
+	values := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	for _, action := range actions {
+		parent, prefix := values, ""
+		if len(actions) > 1 {
+			parent, prefix = getOrCreateMapping(values, []string{action.name}, keyNotation)
+		}
+
+		groups := []struct {
+			name    string
+			section string
+			entries *yaml.Node
+		}{
+			{"inputs", "Inputs", action.inputs},
+			{"outputs", "Outputs", action.outputs},
+			{"secrets", "Secrets", action.secrets},
+		}
+
+		for _, group := range groups {
+			if group.entries == nil || group.entries.Kind != yaml.MappingNode || len(group.entries.Content) == 0 {
+				continue
+			}
+
+			section := group.section
+			if len(actions) > 1 {
+				section = fmt.Sprintf("%s %s", action.name, strings.ToLower(group.section))
+			}
+
+			groupNode := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			groupPrefix := FormatObjectKey(keyNotation, prefix, group.name)
+			for i := 0; i+1 < len(group.entries.Content); i += 2 {
+				key, entry := group.entries.Content[i], group.entries.Content[i+1]
+				entryKey := FormatObjectKey(keyNotation, groupPrefix, key.Value)
+
+				description, valueNode := getActionEntryDescription(key, entry, group.name, keyedDescriptions[entryKey])
+				if description.Section == "" {
+					description.Section = section
+				}
+
+				keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.Value, Line: key.Line, Column: key.Column}
+				groupNode.Content = append(groupNode.Content, keyNode, valueNode)
+				descriptions[entryKey] = description
+			}
+
+			parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: group.name}, groupNode)
+		}
+	}
+
+	if len(actions) == 1 {
+		metadata = actions[0].metadata
+	}
+
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{values}}, descriptions, metadata, nil
+}
+
+// readActionDocument recognizes action metadata files by their runs key, and reusable workflows by their
+// workflow_call trigger
+func readActionDocument(file string, document *yaml.Node) (actionDocument, bool) {
+	action := actionDocument{}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return action, false
+	}
+	root := document.Content[0]
+
+	action.metadata = DocumentMetadata{
+		Title:       scalarValue(mappingValue(root, "name")),
+		Description: scalarValue(mappingValue(root, "description")),
+		Owner:       scalarValue(mappingValue(root, "author")),
+	}
+
+	if mappingValue(root, "runs") != nil {
+		// Actions are named after the directory holding their action.yml
+		action.name = filepath.Base(filepath.Dir(file))
+		action.inputs = mappingValue(root, "inputs")
+		action.outputs = mappingValue(root, "outputs")
+		return action, true
+	}
+
+	if isReusableWorkflow(mappingValue(root, "on")) {
+		action.name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		action.inputs = mappingValue(root, "on", "workflow_call", "inputs")
+		action.outputs = mappingValue(root, "on", "workflow_call", "outputs")
+		action.secrets = mappingValue(root, "on", "workflow_call", "secrets")
+		return action, true
+	}
+
+	return action, false
+}
+
+// isReusableWorkflow reports whether the on key of a workflow lists the workflow_call trigger, in any of its forms
+func isReusableWorkflow(on *yaml.Node) bool {
+	if on == nil {
+		return false
+	}
+
+	switch on.Kind {
+	case yaml.ScalarNode:
+		return on.Value == "workflow_call"
+	case yaml.SequenceNode:
+		for _, trigger := range on.Content {
+			if trigger.Value == "workflow_call" {
+				return true
+			}
+		}
+	case yaml.MappingNode:
+		return mappingValue(on, "workflow_call") != nil
+	}
+
+	return false
+}
+
+// getActionEntryDescription describes an input, output or secret from its fields and the comment above its key, and
+// returns the value node documented for it: the default of an input, or the value expression of an output
+func getActionEntryDescription(key *yaml.Node, entry *yaml.Node, group string, keyed ValueDescription) (ValueDescription, *yaml.Node) {
+	description := keyed
+	if keyed.Description == "" && strings.Contains(key.HeadComment, PrefixComment) {
+		if commentKey, fromComment := ParseComment(strings.Split(key.HeadComment, "\n")); commentKey == "" {
+			description = fromComment
+		}
+	}
+
+	if description.Description == "" {
+		description.Description = strings.TrimSpace(scalarValue(mappingValue(entry, "description")))
+	}
+	description.Required = description.Required || scalarValue(mappingValue(entry, "required")) == "true"
+
+	if message := scalarValue(mappingValue(entry, "deprecationMessage")); message != "" {
+		description.Deprecated = true
+		if description.Description != "" {
+			message = fmt.Sprintf("%s. %s", strings.TrimSuffix(description.Description, "."), message)
+		}
+		description.Description = message
+	}
+
+	if description.ValueType == "" {
+		description.ValueType = "string"
+		if name, ok := jsonSchemaTypeNames[scalarValue(mappingValue(entry, "type"))]; ok {
+			description.ValueType = name
+		}
+	}
+
+	if len(description.AllowedValues) == 0 {
+		if options := mappingValue(entry, "options"); options != nil && options.Kind == yaml.SequenceNode {
+			for _, option := range options.Content {
+				description.AllowedValues = append(description.AllowedValues, option.Value)
+			}
+		}
+	}
+
+	field := "default"
+	if group == "outputs" {
+		field = "value"
+	}
+	if value := mappingValue(entry, field); value != nil && value.Kind == yaml.ScalarNode {
+		return description, value
+	}
+
+	return description, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+}
+
+// scalarValue returns the value of a scalar node, or an empty string for any other node
+func scalarValue(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+
+	return node.Value
+}
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ansibleTypeNames = map[string]string{
+	"bool":  "bool",
+	"dict":  "object",
+	"float": "float",
+	"int":   "int",
+	"list":  "list",
+	"path":  "string",
+	"str":   "string",
+}
+
+type ansibleArgumentSpecs struct {
+	ArgumentSpecs map[string]struct {
+		ShortDescription string                          `yaml:"short_description"`
+		Options          map[string]*ansibleArgumentSpec `yaml:"options"`
+	} `yaml:"argument_specs"`
+}
+
+type ansibleArgumentSpec struct {
+	Type        string                          `yaml:"type"`
+	Elements    string                          `yaml:"elements"`
+	Required    bool                            `yaml:"required"`
+	Default     interface{}                     `yaml:"default"`
+	Choices     []interface{}                   `yaml:"choices"`
+	Description yaml.Node                       `yaml:"description"`
+	Options     map[string]*ansibleArgumentSpec `yaml:"options"`
+}
+
+type ansibleRoleMetadata struct {
+	GalaxyInfo struct {
+		RoleName    string `yaml:"role_name"`
+		Description string `yaml:"description"`
+		Author      string `yaml:"author"`
+	} `yaml:"galaxy_info"`
+}
+
+// parseAnsibleRole documents the variables of an Ansible role. Only the defaults/main.yml file, or the files of a
+// defaults/main directory, are read as values, and their descriptions are completed with the options of the main entry
+// point in meta/argument_specs.yml. Options without a default are added to the values as null.
+func parseAnsibleRole(roleDirectory string, files []string, documentationParsingConfig DocumentationParsingConfig) (*yaml.Node, map[string]ValueDescription, DocumentMetadata, error) {
+	var metadata DocumentMetadata
+
+	var defaultsFiles []string
+	var argumentSpecsFile, metaFile string
+	for _, file := range files {
+		relativePath, err := filepath.Rel(roleDirectory, file)
+		if err != nil {
+			continue
+		}
+
+		relativePath = filepath.ToSlash(relativePath)
+		switch strings.TrimSuffix(relativePath, filepath.Ext(relativePath)) {
+		case "defaults/main":
+			defaultsFiles = append(defaultsFiles, file)
+		case "meta/argument_specs":
+			argumentSpecsFile = file
+		case "meta/main":
+			metaFile = file
+		default:
+			if strings.HasPrefix(relativePath, "defaults/main/") {
+				defaultsFiles = append(defaultsFiles, file)
+			}
+		}
+	}
+
+	values := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	descriptions := make(map[string]ValueDescription)
+	if len(defaultsFiles) > 0 {
+		var err error
+		if values, metadata, err = parseValues(defaultsFiles); err != nil {
+			return nil, nil, metadata, err
+		}
+
+		// Documentation is checked once the argument specs have been merged, as they describe values too
+		lintingConfig := documentationParsingConfig
+		lintingConfig.StrictMode = false
+		if descriptions, err = parseValueDescriptions(defaultsFiles, values, lintingConfig); err != nil {
+			return nil, nil, metadata, err
+		}
+	}
+
+	if argumentSpecsFile != "" {
+		var specs ansibleArgumentSpecs
+		if err := readYamlFile(argumentSpecsFile, &specs); err != nil {
+			return nil, nil, metadata, &ParseError{ConfigPath: argumentSpecsFile, Message: fmt.Sprintf("Error parsing argument specs: %s", err)}
+		}
+
+		if main, ok := specs.ArgumentSpecs["main"]; ok {
+			// Every file of a defaults/main directory is a mapping of its own in the values document
+			mappings := make([]*yaml.Node, 0, len(values.Content))
+			for _, node := range values.Content {
+				if node.Kind == yaml.MappingNode {
+					mappings = append(mappings, node)
+				}
+			}
+			if len(mappings) == 0 {
+				mappings = append(mappings, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"})
+				values.Content = mappings
+			}
+			mergeAnsibleArgumentSpecs(mappings, main.Options, "", descriptions, documentationParsingConfig.KeyNotation)
+
+			if metadata.Description == "" {
+				metadata.Description = main.ShortDescription
+			}
+		}
+	}
+
+	if metaFile != "" {
+		var meta ansibleRoleMetadata
+		if err := readYamlFile(metaFile, &meta); err != nil {
+			return nil, nil, metadata, &ParseError{ConfigPath: metaFile, Message: fmt.Sprintf("Error parsing role metadata: %s", err)}
+		}
+
+		metadata = mergeDocumentMetadata(metadata, DocumentMetadata{
+			Title:       meta.GalaxyInfo.RoleName,
+			Description: meta.GalaxyInfo.Description,
+			Owner:       meta.GalaxyInfo.Author,
+		})
+	}
+
+	if metadata.Title == "" {
+		if absolutePath, err := filepath.Abs(roleDirectory); err == nil {
+			metadata.Title = filepath.Base(absolutePath)
+		}
+	}
+
+	if documentationParsingConfig.StrictMode {
+		if err := checkDocumentation(values, descriptions, documentationParsingConfig); err != nil {
+			return nil, nil, metadata, err
+		}
+	}
+
+	return values, descriptions, metadata, nil
+}
+
+// mergeAnsibleArgumentSpecs completes the descriptions of the variables below the mappings with their argument specs.
+// A variable is looked up in every mapping, the last one setting it taking precedence as in Ansible, and variables
+// set by none are added to the last mapping. Descriptions from comments take precedence, except for the required flag
+// which is set by either.
+func mergeAnsibleArgumentSpecs(mappings []*yaml.Node, options map[string]*ansibleArgumentSpec, prefix string, descriptions map[string]ValueDescription, keyNotation string) {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		spec := options[name]
+		key := FormatObjectKey(keyNotation, prefix, name)
+
+		mapping := mappings[len(mappings)-1]
+		for _, candidate := range mappings {
+			if mappingKey(candidate, name) != nil {
+				mapping = candidate
+			}
+		}
+
+		value := mappingValue(mapping, name)
+		missing := value == nil
+		if missing {
+			value = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+			line := 0
+			if len(mapping.Content) > 0 {
+				line = mapping.Content[len(mapping.Content)-2].Line
+			}
+			mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name, Line: line + 1, Column: 1}, value)
+		}
+
+		// The comment above the key is moved to the descriptions, as flags set by comments otherwise take precedence
+		description, hasDescription := descriptions[key]
+		if keyNode := mappingKey(mapping, name); !hasDescription && keyNode != nil {
+			if description = commentDescription(keyNode); description.Description != "" {
+				keyNode.HeadComment = ""
+			}
+		}
+		descriptions[key] = mergeAnsibleArgumentSpec(description, spec, missing)
+
+		if len(spec.Options) > 0 && value.Kind == yaml.MappingNode {
+			mergeAnsibleArgumentSpecs([]*yaml.Node{value}, spec.Options, key, descriptions, keyNotation)
+		}
+	}
+}
+
+// mergeAnsibleArgumentSpec fills the empty fields of a description from an argument spec. The default of the spec is
+// only documented for variables missing from the defaults files, as the value of the defaults is used otherwise.
+func mergeAnsibleArgumentSpec(description ValueDescription, spec *ansibleArgumentSpec, missing bool) ValueDescription {
+	if description.Description == "" {
+		var lines []string
+		if spec.Description.Kind == yaml.SequenceNode {
+			_ = spec.Description.Decode(&lines)
+		} else if spec.Description.Kind == yaml.ScalarNode {
+			lines = []string{spec.Description.Value}
+		}
+		description.Description = strings.TrimSpace(strings.Join(lines, " "))
+	}
+
+	if description.ValueType == "" {
+		description.ValueType = spec.Type
+		if name, ok := ansibleTypeNames[spec.Type]; ok {
+			description.ValueType = name
+		}
+		if elementType, ok := ansibleTypeNames[spec.Elements]; ok && description.ValueType == "list" {
+			description.ValueType = fmt.Sprintf("list of %s", elementType)
+		}
+	}
+
+	if description.Default == "" && spec.Default != nil && missing {
+		description.Default = fmt.Sprintf("`%s`", jsonValueString(spec.Default))
+	}
+
+	if len(description.AllowedValues) == 0 {
+		for _, choice := range spec.Choices {
+			description.AllowedValues = append(description.AllowedValues, jsonValueString(choice))
+		}
+	}
+
+	description.Required = description.Required || spec.Required
+
+	return description
+}
+
+// commentDescription returns the description from the "# --" comment above a key
+func commentDescription(key *yaml.Node) ValueDescription {
+	if key == nil || !strings.Contains(key.HeadComment, PrefixComment) {
+		return ValueDescription{}
+	}
+
+	commentKey, description := ParseComment(strings.Split(key.HeadComment, "\n"))
+	if commentKey != "" {
+		return ValueDescription{}
+	}
+
+	return description
+}
+
+// mappingKey returns the key node of a mapping node with the given name, or nil when there is none
+func mappingKey(node *yaml.Node, name string) *yaml.Node {
+	keys := mappingKeys(node)
+	if index := slices.IndexFunc(keys, func(key *yaml.Node) bool { return key.Value == name }); index >= 0 {
+		return keys[index]
+	}
+
+	return nil
+}
+
+func readYamlFile(file string, out interface{}) error {
+	contents, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	return yaml.Unmarshal(contents, out)
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+const chartFilename = "Chart.yaml"
+
+// ChartMetadata is the subset of a Helm Chart.yaml file exposed to templates
+type ChartMetadata struct {
+	Name         string            `yaml:"name"`
+	Version      string            `yaml:"version"`
+	AppVersion   string            `yaml:"appVersion"`
+	Description  string            `yaml:"description"`
+	Type         string            `yaml:"type"`
+	KubeVersion  string            `yaml:"kubeVersion"`
+	Home         string            `yaml:"home"`
+	Icon         string            `yaml:"icon"`
+	Deprecated   bool              `yaml:"deprecated"`
+	Sources      []string          `yaml:"sources"`
+	Keywords     []string          `yaml:"keywords"`
+	Maintainers  []ChartMaintainer `yaml:"maintainers"`
+	Dependencies []ChartDependency `yaml:"dependencies"`
+}
+
+type ChartMaintainer struct {
+	Name  string `yaml:"name"`
+	Email string `yaml:"email"`
+	Url   string `yaml:"url"`
+}
+
+type ChartDependency struct {
+	Name       string   `yaml:"name"`
+	Version    string   `yaml:"version"`
+	Repository string   `yaml:"repository"`
+	Alias      string   `yaml:"alias"`
+	Condition  string   `yaml:"condition"`
+	Tags       []string `yaml:"tags"`
+}
+
+// ParseChartFile reads the Chart.yaml file next to a configuration file or in a configuration directory, returning nil
+// when there is none
+func ParseChartFile(configPath string) (*ChartMetadata, error) {
+	chartFile := filepath.Join(getChartDirectory(configPath), chartFilename)
+	if _, err := os.Stat(chartFile); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var chart ChartMetadata
+	if err := readYamlFile(chartFile, &chart); err != nil {
+		return nil, &ParseError{ConfigPath: chartFile, Message: fmt.Sprintf("Error parsing chart: %s", err)}
+	}
+
+	return &chart, nil
+}
+
+// DocumentMetadata returns the document metadata taken from the chart. Its version is left out, as it is rendered in
+// the chart badges.
+func (c *ChartMetadata) DocumentMetadata() DocumentMetadata {
+	return DocumentMetadata{
+		Title:       c.Name,
+		Description: c.Description,
+	}
+}
+
+// removeChartFile removes the Chart.yaml file of a chart from the configuration files, as it does not hold values
+func removeChartFile(configPath string, files []string) []string {
+	chartFile := filepath.Join(getChartDirectory(configPath), chartFilename)
+
+	return slices.DeleteFunc(files, func(file string) bool {
+		return filepath.Clean(file) == chartFile
+	})
+}
+
+func getChartDirectory(configPath string) string {
+	if info, err := os.Stat(configPath); err == nil && !info.IsDir() {
+		return filepath.Dir(configPath)
+	}
+
+	return configPath
+}
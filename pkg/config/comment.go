@@ -1,7 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -47,6 +50,7 @@ func ParseComment(commentLines []string) (string, ValueDescription) {
 		c.Required = strings.Contains(flagTypeMatch[3], "@required")
 		c.Deprecated = strings.Contains(flagTypeMatch[3], "@deprecated")
 		c.Experimental = strings.Contains(flagTypeMatch[3], "@experimental")
+		c.Sensitive = strings.Contains(flagTypeMatch[3], "@sensitive")
 		c.Description = flagTypeMatch[4]
 	}
 
@@ -65,6 +69,12 @@ func ParseComment(commentLines []string) (string, ValueDescription) {
 		sectionCommentMatch := sectionRegex.FindStringSubmatch(line)
 		exampleDescriptionCommentMatch := exampleDescriptionRegex.FindStringSubmatch(line)
 		exampleCommentMatch := exampleRegex.FindStringSubmatch(line)
+		sinceCommentMatch := sinceRegex.FindStringSubmatch(line)
+		removedInCommentMatch := removedInRegex.FindStringSubmatch(line)
+		deprecatedInCommentMatch := deprecatedInRegex.FindStringSubmatch(line)
+		deprecatedProseCommentMatch := deprecatedProseRegex.FindStringSubmatch(line)
+		allowedValuesCommentMatch := allowedValuesRegex.FindStringSubmatch(line)
+		constraintCommentMatch := constraintRegex.FindStringSubmatch(line)
 
 		if !isRaw && len(rawFlagMatch) == 1 {
 			isRaw = true
@@ -76,6 +86,53 @@ func ParseComment(commentLines []string) (string, ValueDescription) {
 			continue
 		}
 
+		if len(sinceCommentMatch) > 1 {
+			c.Since = sinceCommentMatch[1]
+			continue
+		}
+
+		if len(removedInCommentMatch) > 1 {
+			c.RemovedIn = removedInCommentMatch[1]
+			continue
+		}
+
+		if len(deprecatedInCommentMatch) > 1 {
+			c.Deprecated = true
+			c.DeprecatedIn = deprecatedInCommentMatch[1]
+			c.Replacement = deprecatedInCommentMatch[2]
+			continue
+		}
+
+		// Deprecations without a version, e.g. "@deprecated use foo instead", keep their text in the description
+		if len(deprecatedProseCommentMatch) > 1 {
+			c.Deprecated = true
+			c.Description += " " + deprecatedProseCommentMatch[1]
+			continue
+		}
+
+		if len(allowedValuesCommentMatch) > 1 {
+			c.AllowedValues = parseAllowedValues(allowedValuesCommentMatch[1])
+			continue
+		}
+
+		if len(constraintCommentMatch) > 2 {
+			switch constraintCommentMatch[1] {
+			case "minimum":
+				c.Minimum = constraintCommentMatch[2]
+			case "maximum":
+				c.Maximum = constraintCommentMatch[2]
+			case "minLength":
+				c.MinLength = constraintCommentMatch[2]
+			case "maxLength":
+				c.MaxLength = constraintCommentMatch[2]
+			case "pattern":
+				c.Pattern = constraintCommentMatch[2]
+			case "format":
+				c.Format = constraintCommentMatch[2]
+			}
+			continue
+		}
+
 		if len(notationTypeCommentMatch) > 1 {
 			c.NotationType = notationTypeCommentMatch[1]
 			continue
@@ -156,3 +213,59 @@ func ParseComment(commentLines []string) (string, ValueDescription) {
 
 	return valueKey, c
 }
+
+// parseAllowedValues reads a YAML flow sequence such as [a, b, "c d"] into its string representations
+func parseAllowedValues(flowSequence string) []string {
+	var items []interface{}
+	if err := yaml.Unmarshal([]byte(flowSequence), &items); err != nil {
+		return nil
+	}
+
+	allowedValues := make([]string, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			allowedValues = append(allowedValues, "null")
+			continue
+		}
+		allowedValues = append(allowedValues, fmt.Sprint(item))
+	}
+
+	return allowedValues
+}
+
+// ParseDocumentMetadata extracts the @title, @description, @owner and @version annotations from the head comment
+// of a document. Plain comment lines directly following @description are appended to the description.
+func ParseDocumentMetadata(commentLines []string) DocumentMetadata {
+	var m DocumentMetadata
+	var isDescription = false
+
+	for _, line := range commentLines {
+		metadataMatch := documentMetadataRegex.FindStringSubmatch(line)
+
+		if len(metadataMatch) > 2 {
+			isDescription = false
+			switch metadataMatch[1] {
+			case "title":
+				m.Title = metadataMatch[2]
+			case "description":
+				m.Description = metadataMatch[2]
+				isDescription = true
+			case "owner":
+				m.Owner = metadataMatch[2]
+			case "version":
+				m.Version = metadataMatch[2]
+			}
+			continue
+		}
+
+		commentContinuationMatch := commentContinuationRegex.FindStringSubmatch(line)
+		if isDescription && len(commentContinuationMatch) > 1 && commentContinuationMatch[2] != "" && !strings.HasPrefix(commentContinuationMatch[2], "@") && !strings.HasPrefix(line, PrefixComment) {
+			m.Description += " " + commentContinuationMatch[2]
+			continue
+		}
+
+		isDescription = false
+	}
+
+	return m
+}
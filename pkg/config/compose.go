@@ -0,0 +1,217 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Top-level compose keys, other than services, that are documented in a section of their own
+var composeTopLevelSections = map[string]string{
+	"configs":  "Configs",
+	"networks": "Networks",
+	"secrets":  "Secrets",
+	"volumes":  "Volumes",
+}
+
+// Descriptions of common service keys, used when a key is not described by a comment
+var composeServiceDescriptions = map[string]string{
+	"command":     "Command run by the container",
+	"depends_on":  "Services started before this service",
+	"entrypoint":  "Entrypoint of the container",
+	"env_file":    "Files the environment of the container is read from",
+	"image":       "Image the container is started from",
+	"ports":       "Ports published by the container, as `host:container`",
+	"profiles":    "Profiles the service is enabled in",
+	"restart":     "Restart policy of the container",
+	"volumes":     "Volumes mounted in the container, as `source:target`",
+	"working_dir": "Working directory of the container",
+}
+
+const composeExtensionsSection = "Extensions"
+
+// parseComposeFiles documents Docker Compose files. Every service is documented in its own section, and lists of
+// environment variables are documented like mappings so that each variable gets its own row and comments.
+func parseComposeFiles(files []string, documentationParsingConfig DocumentationParsingConfig) (*yaml.Node, map[string]ValueDescription, DocumentMetadata, error) {
+	values, metadata, err := parseValues(files)
+	if err != nil {
+		return nil, nil, metadata, err
+	}
+
+	sectionDescriptions := make(map[string]string)
+	for _, document := range values.Content {
+		if document.Kind != yaml.MappingNode {
+			continue
+		}
+
+		services := mappingValue(document, "services")
+		for _, service := range mappingKeys(services) {
+			sectionDescriptions[service.Value] = getComposeServiceDescription(service, services)
+			if environment := mappingValue(services, service.Value, "environment"); environment != nil {
+				convertAssignmentListToMapping(environment)
+			}
+		}
+	}
+
+	descriptions, err := parseValueDescriptions(files, values, documentationParsingConfig)
+	if err != nil {
+		return nil, nil, metadata, err
+	}
+
+	keyNotation := documentationParsingConfig.KeyNotation
+	for _, document := range values.Content {
+		if document.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for i := 0; i+1 < len(document.Content); i += 2 {
+			key, value := document.Content[i], document.Content[i+1]
+			prefix := FormatObjectKey(keyNotation, "", key.Value)
+
+			switch {
+			case key.Value == "services" && value.Kind == yaml.MappingNode:
+				for j := 0; j+1 < len(value.Content); j += 2 {
+					service := value.Content[j].Value
+					servicePrefix := FormatObjectKey(keyNotation, prefix, service)
+					describeComposeEntries(descriptions, keyNotation, servicePrefix, value.Content[j+1], service, sectionDescriptions[service], "")
+				}
+			case strings.HasPrefix(key.Value, "x-"):
+				describeComposeEntry(descriptions, prefix, key, composeExtensionsSection, "", "Extension field")
+			case composeTopLevelSections[key.Value] != "":
+				describeComposeEntries(descriptions, keyNotation, prefix, value, composeTopLevelSections[key.Value], "", key.Value)
+			}
+		}
+	}
+
+	return values, descriptions, metadata, nil
+}
+
+// getComposeServiceDescription returns the description of a service from the comment above its key, which is removed
+// so that the service is documented by its entries, or else names the image of the service
+func getComposeServiceDescription(service *yaml.Node, services *yaml.Node) string {
+	if strings.Contains(service.HeadComment, PrefixComment) {
+		if key, description := ParseComment(strings.Split(service.HeadComment, "\n")); key == "" {
+			service.HeadComment = ""
+			return description.Description
+		}
+	}
+
+	if image := mappingValue(services, service.Value, "image"); image != nil && image.Kind == yaml.ScalarNode {
+		return fmt.Sprintf("Image `%s`", image.Value)
+	}
+
+	return ""
+}
+
+// convertAssignmentListToMapping rewrites a list of NAME=value entries as a mapping, keeping the comments of each entry.
+// Entries without a value are mapped to null, as in compose their value is taken from the environment.
+func convertAssignmentListToMapping(node *yaml.Node) {
+	if node.Kind != yaml.SequenceNode {
+		return
+	}
+
+	content := make([]*yaml.Node, 0, len(node.Content)*2)
+	for _, item := range node.Content {
+		if item.Kind != yaml.ScalarNode {
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(item.Value, "=")
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name, HeadComment: item.HeadComment, Line: item.Line, Column: item.Column}
+		valueNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null", LineComment: item.LineComment, Line: item.Line, Column: item.Column}
+		if hasValue {
+			valueNode.Tag, valueNode.Value = "!!str", value
+		}
+
+		content = append(content, keyNode, valueNode)
+	}
+
+	node.Kind, node.Tag, node.Content = yaml.MappingNode, "!!map", content
+}
+
+// describeComposeEntries places every entry below the node in the section. Lists such as ports and volumes are
+// documented as a whole, while mappings are documented by their entries.
+func describeComposeEntries(descriptions map[string]ValueDescription, keyNotation string, prefix string, node *yaml.Node, section string, sectionDescription string, parent string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		entryPrefix := FormatObjectKey(keyNotation, prefix, key.Value)
+
+		if value.Kind == yaml.AliasNode {
+			value = value.Alias
+		}
+
+		defaultDescription := ""
+		switch {
+		case strings.HasPrefix(key.Value, "x-"):
+			defaultDescription = "Extension field"
+		case parent == "environment":
+			defaultDescription = fmt.Sprintf("Environment variable `%s`", key.Value)
+		case parent == "":
+			defaultDescription = composeServiceDescriptions[key.Value]
+		}
+
+		if value.Kind == yaml.MappingNode && len(value.Content) > 0 && !strings.HasPrefix(key.Value, "x-") {
+			describeComposeEntries(descriptions, keyNotation, entryPrefix, value, section, sectionDescription, key.Value)
+			continue
+		}
+
+		describeComposeEntry(descriptions, entryPrefix, key, section, sectionDescription, defaultDescription)
+	}
+}
+
+// describeComposeEntry sets the section of an entry, and its default description when it has no description in either
+// a keyed comment or the comment above it
+func describeComposeEntry(descriptions map[string]ValueDescription, key string, keyNode *yaml.Node, section string, sectionDescription string, defaultDescription string) {
+	description := descriptions[key]
+	if description.Section == "" {
+		description.Section = section
+		description.SectionDescription = sectionDescription
+	}
+
+	if description.Description == "" && !strings.Contains(keyNode.HeadComment, PrefixComment) {
+		description.Description = defaultDescription
+	}
+
+	descriptions[key] = description
+}
+
+// mappingKeys returns the key nodes of a mapping node
+func mappingKeys(node *yaml.Node) []*yaml.Node {
+	keys := make([]*yaml.Node, 0)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return keys
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys = append(keys, node.Content[i])
+	}
+
+	return keys
+}
+
+// mappingValue returns the value at the key path below a mapping node, or nil when there is none
+func mappingValue(node *yaml.Node, path ...string) *yaml.Node {
+	for _, name := range path {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		var child *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == name {
+				child = node.Content[i+1]
+			}
+		}
+		node = child
+	}
+
+	return node
+}
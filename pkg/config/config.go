@@ -2,10 +2,14 @@ package config
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
+	"io"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/blakyaks/yaml-docs/pkg/util"
@@ -24,6 +28,18 @@ var sectionRegex = regexp.MustCompile(`^\s*# @section -- (.*)$`)
 var sectionDescriptionRegex = regexp.MustCompile(`^\s*# @sectionDescription(?:\s+(@raw))?\s*-- (.*)$`)
 var exampleDescriptionRegex = regexp.MustCompile(`^\s*# @exampleDescription(?:\s+(@raw))?\s*-- (.*)$`)
 var exampleRegex = regexp.MustCompile(`^\s*# @example\s+(.*?)\s*-- (.*)$`)
+var sinceRegex = regexp.MustCompile(`^\s*# @since\s+(\S+)\s*$`)
+var removedInRegex = regexp.MustCompile(`^\s*# @removedIn\s+(\S+)\s*$`)
+var deprecatedInRegex = regexp.MustCompile(`^\s*# @deprecated\s+(v?\d[\w.+\-]*)(?:\s+--\s*(.*))?\s*$`)
+var deprecatedProseRegex = regexp.MustCompile(`^\s*# @deprecated\s+(.*)$`)
+var allowedValuesRegex = regexp.MustCompile(`^\s*# @(?:enum|allowed)\s+(\[.*\])\s*$`)
+var constraintRegex = regexp.MustCompile(`^\s*# @(minimum|maximum|minLength|maxLength|pattern|format)\s+(.+?)\s*$`)
+var documentMetadataRegex = regexp.MustCompile(`^\s*#\s*@(title|description|owner|version)\s+--\s*(.*)$`)
+
+const (
+	includeTag      = "!include"
+	maxIncludeDepth = 10
+)
 
 type ParseError struct {
 	ConfigPath string
@@ -44,22 +60,59 @@ type ValueDescription struct {
 	ExampleName        string
 	ExampleDescription string
 	Example            string
+	Since              string
+	RemovedIn          string
+	DeprecatedIn       string
+	Replacement        string
+	AllowedValues      []string
+	Minimum            string
+	Maximum            string
+	MinLength          string
+	MaxLength          string
+	Pattern            string
+	Format             string
 	Hidden             bool
 	Required           bool
 	Deprecated         bool
 	Experimental       bool
+	Sensitive          bool
+}
+
+// DocumentMetadata holds the file-level annotations found in the head comment of a document
+type DocumentMetadata struct {
+	Title       string
+	Description string
+	Owner       string
+	Version     string
 }
 
 type DocumentationInfo struct {
 	ConfigPath         string
 	Values             *yaml.Node
 	ValuesDescriptions map[string]ValueDescription
+	Metadata           DocumentMetadata
+	SinceVersions      map[string]string
+	Schema             *JsonSchema
+	Chart              *ChartMetadata
 }
 
+const (
+	ValuesInputMode     = "values"
+	CrdInputMode        = "crd"
+	OpenApiInputMode    = "openapi"
+	ComposeInputMode    = "compose"
+	ActionInputMode     = "action"
+	AnsibleInputMode    = "ansible"
+	KubernetesInputMode = "kubernetes"
+)
+
+var InputModes = []string{ValuesInputMode, CrdInputMode, OpenApiInputMode, ComposeInputMode, ActionInputMode, AnsibleInputMode, KubernetesInputMode}
+
 type DocumentationParsingConfig struct {
 	StrictMode                 bool
 	AllowedMissingValuePaths   []string
 	AllowedMissingValueRegexps []*regexp.Regexp
+	KeyNotation                string
 }
 
 // Main routine to enumerate a config directory contents
@@ -67,6 +120,11 @@ func ParseConfigPath(configDirectory string, documentationParsingConfig Document
 	var chartDocInfo DocumentationInfo
 	var files []string
 
+	inputMode := viper.GetString("input-mode")
+	if inputMode != "" && !slices.Contains(InputModes, inputMode) {
+		return chartDocInfo, fmt.Errorf("invalid input mode %s, must be one of (%s)", inputMode, strings.Join(InputModes, ", "))
+	}
+
 	ignoreFilename := viper.GetString("ignore-file")
 	ignoreContext := util.NewIgnoreContext(ignoreFilename)
 
@@ -76,6 +134,16 @@ func ParseConfigPath(configDirectory string, documentationParsingConfig Document
 		return chartDocInfo, err
 	}
 
+	// The chart metadata of Helm charts is documented separately from their values
+	chart, err := ParseChartFile(configDirectory)
+	if err != nil {
+		return chartDocInfo, err
+	}
+	files = removeChartFile(configDirectory, files)
+	if chart != nil {
+		files = removeSubchartFiles(configDirectory, files)
+	}
+
 	if len(files) == 0 {
 		log.Debugf("No YAML files were found in the path: %s.", configDirectory)
 		return chartDocInfo, &ParseError{
@@ -84,30 +152,115 @@ func ParseConfigPath(configDirectory string, documentationParsingConfig Document
 		}
 	}
 
-	// Get values data from configuration files
-	chartValues, err := parseValues(files)
+	var chartValues *yaml.Node
+	var chartMetadata DocumentMetadata
+	var chartDescriptions map[string]ValueDescription
+
+	switch inputMode {
+	case CrdInputMode:
+		chartValues, chartDescriptions, chartMetadata, err = parseCrdFiles(files, documentationParsingConfig.KeyNotation)
+		if err != nil {
+			return chartDocInfo, err
+		}
+	case OpenApiInputMode:
+		chartValues, chartDescriptions, chartMetadata, err = parseOpenApiFiles(files, documentationParsingConfig.KeyNotation)
+		if err != nil {
+			return chartDocInfo, err
+		}
+	case ComposeInputMode:
+		chartValues, chartDescriptions, chartMetadata, err = parseComposeFiles(files, documentationParsingConfig)
+		if err != nil {
+			return chartDocInfo, err
+		}
+	case ActionInputMode:
+		chartValues, chartDescriptions, chartMetadata, err = parseActionFiles(files, documentationParsingConfig.KeyNotation)
+		if err != nil {
+			return chartDocInfo, err
+		}
+	case AnsibleInputMode:
+		chartValues, chartDescriptions, chartMetadata, err = parseAnsibleRole(configDirectory, files, documentationParsingConfig)
+		if err != nil {
+			return chartDocInfo, err
+		}
+	case KubernetesInputMode:
+		chartValues, chartDescriptions, chartMetadata, err = parseKubernetesFiles(files, documentationParsingConfig.KeyNotation)
+		if err != nil {
+			return chartDocInfo, err
+		}
+	default:
+		// Get values data from configuration files
+		chartValues, chartMetadata, err = parseValues(files)
+		if err != nil {
+			return chartDocInfo, err
+		}
+
+		// Enumerate comments and descriptions from files
+		chartDescriptions, err = parseValueDescriptions(files, chartValues, documentationParsingConfig)
+		if err != nil {
+			return chartDocInfo, err
+		}
+
+		if chart != nil {
+			err = applySubchartValues(getChartDirectory(configDirectory), chart, chartValues, chartDescriptions, documentationParsingConfig)
+			if err != nil {
+				return chartDocInfo, err
+			}
+		}
+	}
+
+	chartDocInfo.ConfigPath = configDirectory
+	chartDocInfo.Values = chartValues
+	chartDocInfo.ValuesDescriptions = chartDescriptions
+	chartDocInfo.Metadata = chartMetadata
+	if chart != nil {
+		chartDocInfo.Chart = chart
+		chartDocInfo.Metadata = mergeDocumentMetadata(chartMetadata, chart.DocumentMetadata())
+	}
+
+	// Use a JSON schema as a fallback source of value descriptions
+	if schemaFile := getSchemaFile(configDirectory); schemaFile != "" {
+		chartDocInfo.Schema, err = ParseJsonSchema(schemaFile)
+		if err != nil {
+			return chartDocInfo, err
+		}
+	}
+
+	return chartDocInfo, nil
+}
+
+// ParseConfigContent parses a single configuration document that has already been read into memory, such as a file
+// taken from a previous git revision
+func ParseConfigContent(configPath string, contents []byte, documentationParsingConfig DocumentationParsingConfig) (DocumentationInfo, error) {
+	var chartDocInfo DocumentationInfo
+
+	contents = []byte(strings.Replace(string(contents), "\r\n", "\n", -1))
+	values, err := parseConfigContent(contents)
 	if err != nil {
-		return chartDocInfo, err
+		return chartDocInfo, &ParseError{
+			ConfigPath: configPath,
+			Message:    fmt.Sprintf("Error parsing YAML content: %s", err),
+		}
 	}
 
-	// Enumerate comments and descriptions from files
-	chartDescriptions, err := parseValueDescriptions(files, chartValues, documentationParsingConfig)
+	chartValues := joinConfigFiles([]yaml.Node{values})
+	chartDescriptions, err := parseConfigComments(bytes.NewReader(contents), &chartValues, documentationParsingConfig)
 	if err != nil {
 		return chartDocInfo, err
 	}
 
-	chartDocInfo.ConfigPath = configDirectory
-	chartDocInfo.Values = chartValues
+	chartDocInfo.ConfigPath = configPath
+	chartDocInfo.Values = &chartValues
 	chartDocInfo.ValuesDescriptions = chartDescriptions
+	chartDocInfo.Metadata = getDocumentMetadata(&values)
 
 	return chartDocInfo, nil
 }
 
 // Helper function that merges multiple documentation info objects into one
-func CombineDocumentationInfo(maps map[string]DocumentationInfo) DocumentationInfo {
+func CombineDocumentationInfo(docInfos map[string]DocumentationInfo) DocumentationInfo {
 
-	if len(maps) == 1 {
-		for _, value := range maps {
+	if len(docInfos) == 1 {
+		for _, value := range docInfos {
 			return value
 		}
 	}
@@ -117,7 +270,10 @@ func CombineDocumentationInfo(maps map[string]DocumentationInfo) DocumentationIn
 		ValuesDescriptions: make(map[string]ValueDescription),
 	}
 
-	for _, docInfo := range maps {
+	// Configuration paths are visited in sorted order, so the first document metadata, schema and chart found are the
+	// same on every run
+	for _, configPath := range slices.Sorted(maps.Keys(docInfos)) {
+		docInfo := docInfos[configPath]
 		combined.ConfigPath += docInfo.ConfigPath
 
 		if docInfo.Values != nil {
@@ -127,6 +283,22 @@ func CombineDocumentationInfo(maps map[string]DocumentationInfo) DocumentationIn
 		for k, v := range docInfo.ValuesDescriptions {
 			combined.ValuesDescriptions[k] = v
 		}
+
+		for k, v := range docInfo.SinceVersions {
+			if combined.SinceVersions == nil {
+				combined.SinceVersions = make(map[string]string)
+			}
+			combined.SinceVersions[k] = v
+		}
+
+		combined.Metadata = mergeDocumentMetadata(combined.Metadata, docInfo.Metadata)
+
+		if combined.Schema == nil {
+			combined.Schema = docInfo.Schema
+		}
+		if combined.Chart == nil {
+			combined.Chart = docInfo.Chart
+		}
 	}
 
 	return combined
@@ -206,7 +378,56 @@ func parseConfigFile(configFile string) (yaml.Node, error) {
 		return values, err
 	}
 
-	err = yaml.Unmarshal(yamlFileContents, &values)
+	values, err = parseConfigContent(yamlFileContents)
+	if err == nil && viper.GetBool("resolve-includes") {
+		resolveIncludes(&values, filepath.Dir(configFile), 0)
+	}
+
+	return values, err
+}
+
+// resolveIncludes replaces scalars tagged !include with the contents of the referenced YAML file. Paths are relative
+// to the directory of the including file, and comments on the include node are kept.
+func resolveIncludes(node *yaml.Node, baseDir string, depth int) {
+	if depth > maxIncludeDepth {
+		log.Warnf("Maximum include depth of %d reached in %s, includes will not be resolved further", maxIncludeDepth, baseDir)
+		return
+	}
+
+	for _, child := range node.Content {
+		if child.Kind != yaml.ScalarNode || child.Tag != includeTag {
+			resolveIncludes(child, baseDir, depth)
+			continue
+		}
+
+		includePath := child.Value
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(baseDir, includePath)
+		}
+
+		includeContents, err := getYamlFileContents(includePath)
+		if err != nil {
+			log.Warnf("Error reading included file %s: %s", includePath, err)
+			continue
+		}
+
+		included, err := parseConfigContent(includeContents)
+		if err != nil || len(included.Content) == 0 {
+			log.Warnf("Error parsing included file %s: %v", includePath, err)
+			continue
+		}
+
+		resolveIncludes(&included, filepath.Dir(includePath), depth+1)
+
+		headComment, lineComment := child.HeadComment, child.LineComment
+		*child = *included.Content[0]
+		child.HeadComment, child.LineComment = headComment, lineComment
+	}
+}
+
+func parseConfigContent(yamlFileContents []byte) (yaml.Node, error) {
+	var values yaml.Node
+	err := yaml.Unmarshal(yamlFileContents, &values)
 	removeIgnored(&values, values.Kind)
 	return values, err
 }
@@ -215,7 +436,7 @@ func checkDocumentation(rootNode *yaml.Node, comments map[string]ValueDescriptio
 	if len(rootNode.Content) == 0 {
 		return nil
 	}
-	valuesWithoutDocs := collectValuesWithoutDoc(rootNode.Content[0], comments, make([]string, 0))
+	valuesWithoutDocs := collectValuesWithoutDoc(rootNode.Content[0], comments, "", config.KeyNotation)
 	valuesWithoutDocsAfterIgnore := make([]string, 0)
 	for _, valueWithoutDoc := range valuesWithoutDocs {
 		ignored := false
@@ -235,30 +456,32 @@ func checkDocumentation(rootNode *yaml.Node, comments map[string]ValueDescriptio
 	return nil
 }
 
-func collectValuesWithoutDoc(node *yaml.Node, comments map[string]ValueDescription, currentPath []string) []string {
+func collectValuesWithoutDoc(node *yaml.Node, comments map[string]ValueDescription, currentPath string, keyNotation string) []string {
 	valuesWithoutDocs := make([]string, 0)
 	switch node.Kind {
 	case yaml.MappingNode:
 		for i := 0; i < len(node.Content); i += 2 {
 			keyNode, valueNode := node.Content[i], node.Content[i+1]
-			currentPath = append(currentPath, keyNode.Value)
-			pathString := strings.Join(currentPath, ".")
+			pathString := joinKeyPath(currentPath, ".", keyNode.Value)
+			if keyNotation != "" && keyNotation != DefaultKeyNotation {
+				pathString = FormatObjectKey(keyNotation, currentPath, keyNode.Value)
+			}
 			if _, ok := comments[pathString]; !ok {
 				valuesWithoutDocs = append(valuesWithoutDocs, pathString)
 			}
 
-			childValuesWithoutDoc := collectValuesWithoutDoc(valueNode, comments, currentPath)
+			childValuesWithoutDoc := collectValuesWithoutDoc(valueNode, comments, pathString, keyNotation)
 			valuesWithoutDocs = append(valuesWithoutDocs, childValuesWithoutDoc...)
-
-			currentPath = currentPath[:len(currentPath)-1]
 		}
 	case yaml.SequenceNode:
 		for i := 0; i < len(node.Content); i++ {
 			valueNode := node.Content[i]
-			currentPath = append(currentPath, fmt.Sprintf("[%d]", i))
-			childValuesWithoutDoc := collectValuesWithoutDoc(valueNode, comments, currentPath)
+			pathString := joinKeyPath(currentPath, ".", fmt.Sprintf("[%d]", i))
+			if keyNotation != "" && keyNotation != DefaultKeyNotation {
+				pathString = FormatListKey(keyNotation, currentPath, i)
+			}
+			childValuesWithoutDoc := collectValuesWithoutDoc(valueNode, comments, pathString, keyNotation)
 			valuesWithoutDocs = append(valuesWithoutDocs, childValuesWithoutDoc...)
-			currentPath = currentPath[:len(currentPath)-1]
 		}
 	}
 	return valuesWithoutDocs
@@ -273,6 +496,10 @@ func parseConfigFileComments(configFile string, values *yaml.Node, lintingConfig
 
 	defer valuesFile.Close()
 
+	return parseConfigComments(valuesFile, values, lintingConfig)
+}
+
+func parseConfigComments(valuesFile io.Reader, values *yaml.Node, lintingConfig DocumentationParsingConfig) (map[string]ValueDescription, error) {
 	keyToDescriptions := make(map[string]ValueDescription)
 	scanner := bufio.NewScanner(valuesFile)
 	foundValuesComment := false
@@ -283,6 +510,11 @@ func parseConfigFileComments(configFile string, values *yaml.Node, lintingConfig
 		currentLineIdx++
 		currentLine := scanner.Text()
 
+		// File-level metadata annotations are not value descriptions and are handled separately
+		if !foundValuesComment && documentMetadataRegex.MatchString(currentLine) {
+			continue
+		}
+
 		// If we've not yet found a values comment with a key name, try and find one on each line
 		if !foundValuesComment {
 			match := valuesDescriptionRegex.FindStringSubmatch(currentLine)
@@ -354,9 +586,10 @@ func joinConfigFiles(valuesNodes []yaml.Node) yaml.Node {
 	return mergedValues
 }
 
-func parseValues(configFileNames []string) (*yaml.Node, error) {
+func parseValues(configFileNames []string) (*yaml.Node, DocumentMetadata, error) {
 
 	valuesNodes := make([]yaml.Node, len(configFileNames))
+	var metadata DocumentMetadata
 
 	for idx, valuesFile := range configFileNames {
 		values, err := parseConfigFile(valuesFile)
@@ -371,10 +604,41 @@ func parseValues(configFileNames []string) (*yaml.Node, error) {
 		}
 
 		valuesNodes[idx] = values
+		metadata = mergeDocumentMetadata(metadata, getDocumentMetadata(&values))
 	}
 
 	mergedValues := joinConfigFiles(valuesNodes)
-	return &mergedValues, nil
+	return &mergedValues, metadata, nil
+}
+
+// getDocumentMetadata reads the file-level annotations from the head comment of the document node. When the
+// annotations are not separated from the first key by a blank line, the YAML parser attaches them to that key instead.
+func getDocumentMetadata(document *yaml.Node) DocumentMetadata {
+	commentLines := strings.Split(document.HeadComment, "\n")
+
+	if len(document.Content) > 0 && document.Content[0].Kind == yaml.MappingNode && len(document.Content[0].Content) > 0 {
+		commentLines = append(commentLines, strings.Split(document.Content[0].Content[0].HeadComment, "\n")...)
+	}
+
+	return ParseDocumentMetadata(commentLines)
+}
+
+// mergeDocumentMetadata fills any empty fields of the first metadata object from the second
+func mergeDocumentMetadata(metadata DocumentMetadata, other DocumentMetadata) DocumentMetadata {
+	if metadata.Title == "" {
+		metadata.Title = other.Title
+	}
+	if metadata.Description == "" {
+		metadata.Description = other.Description
+	}
+	if metadata.Owner == "" {
+		metadata.Owner = other.Owner
+	}
+	if metadata.Version == "" {
+		metadata.Version = other.Version
+	}
+
+	return metadata
 }
 
 func parseValueDescriptions(configFileNames []string, values *yaml.Node, lintingConfig DocumentationParsingConfig) (map[string]ValueDescription, error) {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v3"
 )
 
 type ConfigParsingTestSuite struct {
@@ -97,3 +98,388 @@ func (suite *ConfigParsingTestSuite) TestFullyDocumentedChartStrictModeOn() {
 	})
 	suite.NoError(err)
 }
+
+func (suite *ConfigParsingTestSuite) TestDocumentMetadata() {
+	configPath := filepath.Join("test-fixtures", "document-metadata")
+	info, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{
+		StrictMode: false,
+	})
+	suite.NoError(err)
+	suite.Equal(config.DocumentMetadata{
+		Title:       "Ingress Controller",
+		Description: "Configuration for the ingress controller. Values are read at startup.",
+		Owner:       "platform-team",
+		Version:     "1.4.0",
+	}, info.Metadata)
+	suite.NotContains(info.ValuesDescriptions, "@title")
+}
+
+func (suite *ConfigParsingTestSuite) TestCombinedDocumentMetadata() {
+	docInfos := map[string]config.DocumentationInfo{
+		"b": {ConfigPath: "b", Metadata: config.DocumentMetadata{Title: "Second", Owner: "platform-team"}},
+		"a": {ConfigPath: "a", Metadata: config.DocumentMetadata{Title: "First"}},
+		"c": {ConfigPath: "c", Metadata: config.DocumentMetadata{Title: "Third", Version: "1.0.0"}},
+	}
+
+	for i := 0; i < 10; i++ {
+		combined := config.CombineDocumentationInfo(docInfos)
+		suite.Equal(config.DocumentMetadata{Title: "First", Owner: "platform-team", Version: "1.0.0"}, combined.Metadata)
+		suite.Equal("abc", combined.ConfigPath)
+	}
+}
+
+func (suite *ConfigParsingTestSuite) TestResolveIncludes() {
+	viper.Set("resolve-includes", true)
+	defer viper.Set("resolve-includes", false)
+
+	configPath := filepath.Join("test-fixtures", "includes", "values.yaml")
+	info, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{})
+	suite.NoError(err)
+
+	database := info.Values.Content[0].Content[1]
+	suite.Equal(yaml.MappingNode, database.Kind)
+	suite.Equal("host", database.Content[0].Value)
+	suite.Equal("5432", database.Content[3].Value)
+}
+
+func (suite *ConfigParsingTestSuite) TestNotFullyDocumentedChartStrictModeOnKeyNotation() {
+	configPath := filepath.Join("test-fixtures", "full-template")
+	_, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{
+		StrictMode:  true,
+		KeyNotation: config.JsonPointerKeyNotation,
+		AllowedMissingValuePaths: []string{
+			"/controller",
+			"/controller/name",
+			"/controller/image",
+			"/controller/image/repository",
+			"/controller/image/tag",
+			"/controller/extraVolumes",
+			"/controller/extraVolumes/0/configMap",
+			"/controller/extraVolumes/0/configMap/name",
+			"/controller/publishService",
+			"/controller/service",
+			"/controller/service/annotations",
+			"/controller/service/type",
+		},
+	})
+	// The fixture documents its values with keys in the default notation, which don't match JSON Pointer paths
+	expectedError := `values without documentation: 
+/controller/persistentVolumeClaims
+/controller/extraVolumes/0/name
+/controller/ingressClass
+/controller/podLabels
+/controller/publishService/enabled
+/controller/replicas
+/controller/service/annotations/external-dns.alpha.kubernetes.io~1hostname`
+	suite.EqualError(err, expectedError)
+}
+
+func (suite *ConfigParsingTestSuite) TestKeyNotations() {
+	for notation, expected := range map[string]string{
+		config.DefaultKeyNotation:     `controller.extraVolumes[0]."config.map"`,
+		config.JsonPathKeyNotation:    `$.controller.extraVolumes[0]['config.map']`,
+		config.JsonPointerKeyNotation: `/controller/extraVolumes/0/config.map`,
+		config.HelmKeyNotation:        `controller.extraVolumes[0].config\.map`,
+		config.EnvKeyNotation:         `CONTROLLER_EXTRAVOLUMES_0_CONFIG_MAP`,
+	} {
+		key := config.FormatObjectKey(notation, "", "controller")
+		key = config.FormatObjectKey(notation, key, "extraVolumes")
+		key = config.FormatListKey(notation, key, 0)
+		key = config.FormatObjectKey(notation, key, "config.map")
+		suite.Equal(expected, key, notation)
+	}
+}
+
+func (suite *ConfigParsingTestSuite) TestJsonSchema() {
+	viper.Set("schema-file", "values.schema.json")
+	defer viper.Set("schema-file", "")
+
+	configPath := filepath.Join("test-fixtures", "schema")
+	info, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{})
+	suite.NoError(err)
+	suite.NotNil(info.Schema)
+
+	replicas := info.Schema.Property("replicas")
+	suite.Equal(config.ValueDescription{
+		Description: "Number of controller replicas",
+		ValueType:   "int",
+		Minimum:     "1",
+	}, replicas.ValueDescription("replicas"))
+
+	tag := info.Schema.Property("image").Property("tag")
+	suite.Equal(config.ValueDescription{
+		Description:   "Image tag",
+		ValueType:     "string",
+		AllowedValues: []string{"latest", "stable"},
+		Example:       "tag: stable",
+	}, tag.ValueDescription("tag"))
+
+	suite.Nil(info.Schema.Property("missing"))
+}
+
+func (suite *ConfigParsingTestSuite) TestCrdInputMode() {
+	viper.Set("input-mode", config.CrdInputMode)
+	defer viper.Set("input-mode", config.ValuesInputMode)
+
+	configPath := filepath.Join("test-fixtures", "crd")
+	info, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{})
+	suite.NoError(err)
+
+	suite.Equal("Backup", info.Metadata.Title)
+	suite.Equal(config.ValueDescription{
+		Description:        "Number of backups to keep",
+		ValueType:          "int",
+		Default:            "`7`",
+		Minimum:            "1",
+		Section:            "v1",
+		SectionDescription: "API version `example.com/v1`",
+	}, info.ValuesDescriptions["v1.spec.retention"])
+	suite.True(info.ValuesDescriptions["v1.spec.schedule"].Required)
+	suite.Equal([]string{"s3", "gcs"}, info.ValuesDescriptions["v1.spec.storage"].AllowedValues)
+	suite.Equal("Name of the database", info.ValuesDescriptions["v1.spec.targets[0].name"].Description)
+	suite.Equal("API version `example.com/v1alpha1` (deprecated) use v1", info.ValuesDescriptions["v1alpha1.spec.schedule"].SectionDescription)
+	suite.NotContains(info.ValuesDescriptions, "v1.apiVersion")
+}
+
+func (suite *ConfigParsingTestSuite) TestOpenApiInputMode() {
+	viper.Set("input-mode", config.OpenApiInputMode)
+	defer viper.Set("input-mode", config.ValuesInputMode)
+
+	configPath := filepath.Join("test-fixtures", "openapi")
+	info, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{})
+	suite.NoError(err)
+
+	suite.Equal("Pet Store", info.Metadata.Title)
+	suite.Equal("1.2.0", info.Metadata.Version)
+	suite.Equal(config.ValueDescription{
+		Description:        "Sale status of the pet",
+		ValueType:          "string",
+		Default:            "`available`",
+		AllowedValues:      []string{"available", "sold"},
+		Section:            "Pet",
+		SectionDescription: "A pet for sale",
+	}, info.ValuesDescriptions["Pet.status"])
+	suite.Equal("A pet for sale", info.ValuesDescriptions["Pet"].Description)
+	suite.True(info.ValuesDescriptions["Pet.id"].Required)
+	suite.True(info.ValuesDescriptions["Pet.name"].Required)
+	suite.Equal("id: a1b2c3", info.ValuesDescriptions["Resource.id"].Example)
+	suite.Equal("Name of the owner", info.ValuesDescriptions["Pet.owner.name"].Description)
+	suite.Equal("Pets of the owner", info.ValuesDescriptions["Owner.pets"].Description)
+	suite.Equal("Owner", info.ValuesDescriptions["Owner.pets[0].name"].Section)
+	suite.NotContains(info.ValuesDescriptions, "Pet.owner.pets[0].owner.name")
+
+	// Properties with a null or boolean schema are listed without a description
+	suite.Equal(config.ValueDescription{Section: "Owner", SectionDescription: "Owner of a pet"}, info.ValuesDescriptions["Owner.notes"])
+	suite.Equal(config.ValueDescription{Section: "Owner", SectionDescription: "Owner of a pet"}, info.ValuesDescriptions["Owner.extra"])
+}
+
+func (suite *ConfigParsingTestSuite) TestComposeInputMode() {
+	viper.Set("input-mode", config.ComposeInputMode)
+	defer viper.Set("input-mode", config.ValuesInputMode)
+
+	configPath := filepath.Join("test-fixtures", "compose")
+	info, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{})
+	suite.NoError(err)
+
+	web := info.ValuesDescriptions["services.web.image"]
+	suite.Equal("web", web.Section)
+	suite.Equal("Web frontend of the shop", web.SectionDescription)
+	suite.Equal("Image the container is started from", web.Description)
+	suite.Equal("Image `shop/api:latest`", info.ValuesDescriptions["services.api.volumes"].SectionDescription)
+	suite.Equal("Environment variable `DEBUG`", info.ValuesDescriptions["services.web.environment.DEBUG"].Description)
+	suite.Equal("", info.ValuesDescriptions["services.web.environment.API_URL"].Description)
+	suite.Equal("Extension field", info.ValuesDescriptions["services.api.x-owner"].Description)
+	suite.Equal("Extensions", info.ValuesDescriptions["x-logging"].Section)
+	suite.Equal("Volumes", info.ValuesDescriptions["volumes.uploads"].Section)
+
+	environment := info.Values.Content[0].Content[5].Content[1].Content[5]
+	suite.Equal(yaml.MappingNode, environment.Kind)
+	suite.Equal("API_URL", environment.Content[0].Value)
+	suite.Equal("http://api:3000", environment.Content[1].Value)
+	suite.Contains(environment.Content[0].HeadComment, "Address of the API")
+	suite.Equal("!!null", environment.Content[3].Tag)
+}
+
+func (suite *ConfigParsingTestSuite) TestActionInputMode() {
+	viper.Set("input-mode", config.ActionInputMode)
+	defer viper.Set("input-mode", config.ValuesInputMode)
+
+	configPath := filepath.Join("test-fixtures", "action")
+	info, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{})
+	suite.NoError(err)
+
+	suite.Equal(config.DocumentMetadata{Title: "Setup tool", Description: "Installs the tool and adds it to the PATH", Owner: "Platform team"}, info.Metadata)
+	suite.Equal(config.ValueDescription{
+		Description: "Version of the tool to install",
+		ValueType:   "string",
+		Section:     "Inputs",
+		Required:    true,
+	}, info.ValuesDescriptions["inputs.version"])
+	suite.Equal("Directory the tool is installed in, relative to the workspace", info.ValuesDescriptions["inputs.install-dir"].Description)
+	suite.True(info.ValuesDescriptions["inputs.cache"].Deprecated)
+	suite.Equal("Whether to cache the download. Caching is always enabled.", info.ValuesDescriptions["inputs.cache"].Description)
+	suite.Equal("Outputs", info.ValuesDescriptions["outputs.path"].Section)
+
+	inputs := info.Values.Content[0].Content[1]
+	suite.Equal("install-dir", inputs.Content[2].Value)
+	suite.Equal(".tools", inputs.Content[3].Value)
+	suite.Empty(inputs.Content[2].HeadComment)
+}
+
+func (suite *ConfigParsingTestSuite) TestActionInputModeReusableWorkflow() {
+	viper.Set("input-mode", config.ActionInputMode)
+	defer viper.Set("input-mode", config.ValuesInputMode)
+
+	configPath := filepath.Join("test-fixtures", "workflow")
+	info, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{})
+	suite.NoError(err)
+
+	suite.Equal("Deploy", info.Metadata.Title)
+	suite.Equal([]string{"staging", "production"}, info.ValuesDescriptions["inputs.environment"].AllowedValues)
+	suite.Equal("bool", info.ValuesDescriptions["inputs.dry-run"].ValueType)
+	suite.True(info.ValuesDescriptions["secrets.token"].Required)
+	suite.Equal("Secrets", info.ValuesDescriptions["secrets.token"].Section)
+}
+
+func (suite *ConfigParsingTestSuite) TestAnsibleInputMode() {
+	viper.Set("input-mode", config.AnsibleInputMode)
+	defer viper.Set("input-mode", config.ValuesInputMode)
+
+	configPath := filepath.Join("test-fixtures", "ansible")
+	info, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{})
+	suite.NoError(err)
+
+	suite.Equal(config.DocumentMetadata{Title: "nginx", Description: "Installs and configures nginx", Owner: "Web team"}, info.Metadata)
+	suite.Equal(config.ValueDescription{
+		Description: "Port nginx listens on",
+		ValueType:   "int",
+		Required:    true,
+	}, info.ValuesDescriptions["nginx_port"])
+	suite.Equal("Number of worker processes. Set to auto to use one per CPU.", info.ValuesDescriptions["nginx_worker_processes"].Description)
+	suite.Equal("object", info.ValuesDescriptions["nginx_sites"].ValueType)
+	suite.Equal(config.ValueDescription{
+		Description:   "Whether nginx is installed",
+		ValueType:     "string",
+		Default:       "`present`",
+		AllowedValues: []string{"present", "absent"},
+	}, info.ValuesDescriptions["nginx_state"])
+
+	// Only the defaults are read as values, with the options missing from them added as null
+	values := info.Values.Content[0]
+	suite.Len(values.Content, 8)
+	suite.Equal("nginx_state", values.Content[6].Value)
+	suite.Equal("!!null", values.Content[7].Tag)
+}
+
+func (suite *ConfigParsingTestSuite) TestAnsibleInputModeStrictMode() {
+	viper.Set("input-mode", config.AnsibleInputMode)
+	defer viper.Set("input-mode", config.ValuesInputMode)
+
+	configPath := filepath.Join("test-fixtures", "ansible")
+	_, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{StrictMode: true})
+	suite.EqualError(err, "values without documentation: \nnginx_sites.default\nnginx_sites.default.root")
+}
+
+func (suite *ConfigParsingTestSuite) TestAnsibleInputModeDefaultsDirectory() {
+	viper.Set("input-mode", config.AnsibleInputMode)
+	defer viper.Set("input-mode", config.ValuesInputMode)
+
+	configPath := filepath.Join("test-fixtures", "ansible-defaults-directory")
+	info, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{})
+	suite.NoError(err)
+
+	suite.Equal(config.ValueDescription{Description: "Set in the first defaults file", ValueType: "string"}, info.ValuesDescriptions["a_var"])
+	suite.Equal(config.ValueDescription{Description: "Set in the second defaults file", ValueType: "string", Required: true}, info.ValuesDescriptions["b_var"])
+	suite.Equal("`false`", info.ValuesDescriptions["c_var"].Default)
+
+	// Options set by any defaults file are not added again, the others are added to the last file
+	suite.Len(info.Values.Content, 2)
+	suite.Len(info.Values.Content[0].Content, 2)
+	suite.Equal("a_var", info.Values.Content[0].Content[0].Value)
+	suite.Len(info.Values.Content[1].Content, 4)
+	suite.Equal("b_var", info.Values.Content[1].Content[0].Value)
+	suite.Equal("two", info.Values.Content[1].Content[1].Value)
+	suite.Equal("c_var", info.Values.Content[1].Content[2].Value)
+	suite.Equal("!!null", info.Values.Content[1].Content[3].Tag)
+}
+
+func (suite *ConfigParsingTestSuite) TestChartMetadata() {
+	configPath := filepath.Join("test-fixtures", "chart")
+	info, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{})
+	suite.NoError(err)
+
+	suite.Equal(config.DocumentMetadata{Title: "shop", Description: "Online shop"}, info.Metadata)
+	suite.Equal("2.3.0", info.Chart.AppVersion)
+	suite.Equal([]string{"https://github.com/example/shop"}, info.Chart.Sources)
+	suite.Equal([]config.ChartMaintainer{{Name: "Web team", Email: "web@example.com"}}, info.Chart.Maintainers)
+	suite.Equal("postgresql.enabled", info.Chart.Dependencies[0].Condition)
+
+	// The Chart.yaml file is not documented as values
+	suite.Len(info.Values.Content, 1)
+	suite.Equal("replicaCount", info.Values.Content[0].Content[0].Value)
+}
+
+func (suite *ConfigParsingTestSuite) TestSubchartValues() {
+	configPath := filepath.Join("test-fixtures", "umbrella")
+	info, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{})
+	suite.NoError(err)
+
+	// Subchart values are documented under the alias of their dependency, with the overrides of the parent
+	db := info.Values.Content[0].Content[3]
+	suite.Equal("db", info.Values.Content[0].Content[2].Value)
+	suite.Equal("platform", db.Content[1].Content[1].Value)
+	suite.Contains(db.Content[1].Content[0].HeadComment, "Name of the database created on startup")
+	suite.Equal("Port of the server", info.ValuesDescriptions["db.port"].Description)
+	suite.Equal("redis", info.Values.Content[0].Content[4].Value)
+	suite.Len(info.Values.Content, 1)
+}
+
+func (suite *ConfigParsingTestSuite) TestSubchartValuesOverrides() {
+	viper.Set("subchart-values", config.OverrideSubchartValues)
+	defer viper.Set("subchart-values", config.AllSubchartValues)
+
+	configPath := filepath.Join("test-fixtures", "umbrella")
+	info, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{})
+	suite.NoError(err)
+
+	root := info.Values.Content[0]
+	suite.Len(root.Content, 4)
+	suite.Len(root.Content[3].Content[1].Content, 2)
+	suite.Equal("Name of the database created on startup", info.ValuesDescriptions["db.auth.database"].Description)
+	suite.NotContains(info.ValuesDescriptions, "db.auth.username")
+}
+
+func (suite *ConfigParsingTestSuite) TestInvalidInputMode() {
+	viper.Set("input-mode", "crds")
+	defer viper.Set("input-mode", config.ValuesInputMode)
+
+	configPath := filepath.Join("test-fixtures", "document-metadata")
+	_, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{})
+	suite.EqualError(err, "invalid input mode crds, must be one of (values, crd, openapi, compose, action, ansible, kubernetes)")
+}
+
+func (suite *ConfigParsingTestSuite) TestKubernetesInputMode() {
+	viper.Set("input-mode", config.KubernetesInputMode)
+	defer viper.Set("input-mode", config.ValuesInputMode)
+
+	configPath := filepath.Join("test-fixtures", "kubernetes")
+	info, err := config.ParseConfigPath(configPath, config.DocumentationParsingConfig{})
+	suite.NoError(err)
+
+	suite.Equal(config.ValueDescription{
+		Section:            "platform-settings",
+		SectionDescription: "Settings shared by the platform services",
+	}, info.ValuesDescriptions["platform-settings.LOG_LEVEL"])
+	suite.Equal("Feature flags of the app", info.ValuesDescriptions["features.NEW_CHECKOUT"].SectionDescription)
+	suite.True(info.ValuesDescriptions["credentials.DB_PASSWORD"].Sensitive)
+	suite.Equal("Replacements", info.ValuesDescriptions["replacements[0]"].Section)
+	suite.NotContains(info.ValuesDescriptions, "metadata.name")
+
+	root := info.Values.Content[0]
+	suite.Equal([]string{"platform-settings", "features", "credentials", "replacements"}, []string{root.Content[0].Value, root.Content[2].Value, root.Content[4].Value, root.Content[6].Value})
+	suite.Contains(root.Content[1].Content[0].HeadComment, "Level of logs in production")
+	suite.Equal([]string{"LOG_LEVEL", "warn", "GATEWAY_URL"}, []string{root.Content[1].Content[0].Value, root.Content[1].Content[1].Value, root.Content[1].Content[2].Value})
+	suite.Len(root.Content[1].Content, 4)
+	suite.NotContains(info.ValuesDescriptions, "platform-settings.DEBUG_TOKEN")
+	suite.Equal("ConfigMap/platform-settings data.GATEWAY_URL -> Deployment/app spec.template.metadata.annotations.gateway", root.Content[7].Content[0].Value)
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// Properties common to every Kubernetes resource, which are not documented for custom resources
+var crdStandardProperties = []string{"apiVersion", "kind", "metadata"}
+
+type customResourceDefinition struct {
+	Kind string `yaml:"kind"`
+	Spec struct {
+		Group string `yaml:"group"`
+		Names struct {
+			Kind string `yaml:"kind"`
+		} `yaml:"names"`
+		Versions []customResourceDefinitionVersion `yaml:"versions"`
+	} `yaml:"spec"`
+}
+
+type customResourceDefinitionVersion struct {
+	Name               string `yaml:"name"`
+	Deprecated         bool   `yaml:"deprecated"`
+	DeprecationWarning string `yaml:"deprecationWarning"`
+	Schema             struct {
+		OpenAPIV3Schema yaml.Node `yaml:"openAPIV3Schema"`
+	} `yaml:"schema"`
+}
+
+// parseCrdFiles documents the openAPIV3Schema of every version of the CustomResourceDefinitions found in the files.
+// Each version is placed in its own section, and the keys are prefixed with the kind and version when the files
+// define more than one of them.
+func parseCrdFiles(files []string, keyNotation string) (*yaml.Node, map[string]ValueDescription, DocumentMetadata, error) {
+	var metadata DocumentMetadata
+
+	crds := make([]customResourceDefinition, 0)
+	for _, file := range files {
+		fileCrds, err := readCrdFile(file)
+		if err != nil {
+			return nil, nil, metadata, &ParseError{ConfigPath: file, Message: fmt.Sprintf("Error parsing CustomResourceDefinition: %s", err)}
+		}
+		crds = append(crds, fileCrds...)
+	}
+
+	builder := newSchemaValuesBuilder(keyNotation)
+	values := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	for _, crd := range crds {
+		for _, version := range crd.Spec.Versions {
+			schema, err := parseYamlSchema(&version.Schema.OpenAPIV3Schema)
+			if err != nil {
+				return nil, nil, metadata, fmt.Errorf("failed to parse the schema of %s %s: %s", crd.Spec.Names.Kind, version.Name, err)
+			}
+
+			// Only the fields of the custom resource itself are documented
+			properties := make(map[string]*JsonSchema)
+			for name, property := range schema.Properties {
+				if !slices.Contains(crdStandardProperties, name) {
+					properties[name] = property
+				}
+			}
+			schema.Properties = properties
+
+			path := make([]string, 0, 2)
+			section := version.Name
+			if len(crds) > 1 {
+				path = append(path, crd.Spec.Names.Kind)
+				section = fmt.Sprintf("%s %s", crd.Spec.Names.Kind, version.Name)
+			}
+			if len(crd.Spec.Versions) > 1 {
+				path = append(path, version.Name)
+			}
+
+			sectionDescription := fmt.Sprintf("API version `%s/%s`", crd.Spec.Group, version.Name)
+			if version.Deprecated {
+				sectionDescription += fmt.Sprintf(" (deprecated) %s", version.DeprecationWarning)
+			}
+
+			parent, prefix := getOrCreateMapping(values, path, keyNotation)
+			versionValues := builder.objectValues(schema, prefix, section, strings.TrimSpace(sectionDescription))
+			parent.Content = append(parent.Content, versionValues.Content...)
+		}
+	}
+
+	if len(crds) == 1 {
+		metadata.Title = crds[0].Spec.Names.Kind
+		metadata.Description = fmt.Sprintf("Custom resource of the `%s` API group.", crds[0].Spec.Group)
+	}
+
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{values}}, builder.descriptions, metadata, nil
+}
+
+func readCrdFile(file string) ([]customResourceDefinition, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	crds := make([]customResourceDefinition, 0)
+	decoder := yaml.NewDecoder(f)
+	for {
+		var crd customResourceDefinition
+		err := decoder.Decode(&crd)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if crd.Kind != "CustomResourceDefinition" {
+			log.Debugf("Skipping %s document in %s", crd.Kind, file)
+			continue
+		}
+		crds = append(crds, crd)
+	}
+
+	return crds, nil
+}
+
+// getOrCreateMapping returns the mapping node at the key path below the values, creating it when needed, along with
+// the key of that mapping in the given notation
+func getOrCreateMapping(values *yaml.Node, path []string, keyNotation string) (*yaml.Node, string) {
+	node, prefix := values, ""
+
+	for _, name := range path {
+		prefix = FormatObjectKey(keyNotation, prefix, name)
+
+		var child *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == name {
+				child = node.Content[i+1]
+			}
+		}
+
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, child)
+		}
+		node = child
+	}
+
+	return node, prefix
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const kubernetesReplacementsSection = "Replacements"
+
+// parseKubernetesFiles documents the data keys of ConfigMaps, and the literals of the configMapGenerator and
+// secretGenerator entries and the replacements of kustomizations. Every ConfigMap or generator is documented in a
+// section named after it, with its keys below its name, and other resources are skipped.
+func parseKubernetesFiles(files []string, keyNotation string) (*yaml.Node, map[string]ValueDescription, DocumentMetadata, error) {
+	var metadata DocumentMetadata
+
+	values := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	descriptions := make(map[string]ValueDescription)
+
+	addEntries := func(name string, entries *yaml.Node, sectionDescription string, sensitive bool) {
+		if name == "" || entries == nil || entries.Kind != yaml.MappingNode {
+			return
+		}
+
+		parent, prefix := getOrCreateMapping(values, []string{name}, keyNotation)
+		for i := 0; i+1 < len(entries.Content); i += 2 {
+			key := FormatObjectKey(keyNotation, prefix, entries.Content[i].Value)
+
+			// Keys of a ConfigMap or generator set by several files are listed once, with the value of the last file
+			if index := mappingKeyIndex(parent, entries.Content[i].Value); index >= 0 {
+				parent.Content[index], parent.Content[index+1] = entries.Content[i], entries.Content[i+1]
+			} else {
+				parent.Content = append(parent.Content, entries.Content[i], entries.Content[i+1])
+			}
+
+			description := descriptions[key]
+			description.Section = name
+			if sectionDescription != "" {
+				description.SectionDescription = sectionDescription
+			}
+			description.Sensitive = description.Sensitive || sensitive
+			descriptions[key] = description
+		}
+	}
+
+	replacements := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, file := range files {
+		documents, err := readYamlDocuments(file)
+		if err != nil {
+			return nil, nil, metadata, &ParseError{ConfigPath: file, Message: fmt.Sprintf("Error parsing manifests: %s", err)}
+		}
+
+		for _, document := range documents {
+			root := document.Content[0]
+
+			if scalarValue(mappingValue(root, "kind")) == "ConfigMap" {
+				data := mappingValue(root, "data")
+				addEntries(scalarValue(mappingValue(root, "metadata", "name")), data, commentDescription(mappingKey(root, "data")).Description, false)
+				continue
+			}
+
+			for _, generators := range []struct {
+				key       string
+				sensitive bool
+			}{{"configMapGenerator", false}, {"secretGenerator", true}} {
+				for _, generator := range sequenceItems(mappingValue(root, generators.key)) {
+					literals := mappingValue(generator, "literals")
+					if literals == nil {
+						continue
+					}
+
+					convertAssignmentListToMapping(literals)
+					addEntries(scalarValue(mappingValue(generator, "name")), literals, commentDescription(generator).Description, generators.sensitive)
+				}
+			}
+
+			for _, replacement := range sequenceItems(mappingValue(root, "replacements")) {
+				replacements.Content = append(replacements.Content, &yaml.Node{
+					Kind:        yaml.ScalarNode,
+					Tag:         "!!str",
+					Value:       getReplacementSummary(replacement),
+					HeadComment: replacement.HeadComment,
+					Line:        replacement.Line,
+					Column:      replacement.Column,
+				})
+			}
+		}
+	}
+
+	if len(replacements.Content) > 0 {
+		prefix := FormatObjectKey(keyNotation, "", "replacements")
+		for i := range replacements.Content {
+			descriptions[FormatListKey(keyNotation, prefix, i)] = ValueDescription{Section: kubernetesReplacementsSection}
+		}
+		values.Content = append(values.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "replacements"}, replacements)
+	}
+
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{values}}, descriptions, metadata, nil
+}
+
+// getReplacementSummary describes a kustomize replacement as its source field followed by the fields it is copied to
+func getReplacementSummary(replacement *yaml.Node) string {
+	source := mappingValue(replacement, "source")
+	fieldPath := scalarValue(mappingValue(source, "fieldPath"))
+	if fieldPath == "" {
+		fieldPath = "metadata.name"
+	}
+
+	targets := make([]string, 0)
+	for _, target := range sequenceItems(mappingValue(replacement, "targets")) {
+		fieldPaths := make([]string, 0)
+		for _, path := range sequenceItems(mappingValue(target, "fieldPaths")) {
+			fieldPaths = append(fieldPaths, path.Value)
+		}
+
+		targets = append(targets, fmt.Sprintf("%s %s", getResourceName(mappingValue(target, "select")), strings.Join(fieldPaths, ", ")))
+	}
+
+	return fmt.Sprintf("%s %s -> %s", getResourceName(source), fieldPath, strings.Join(targets, "; "))
+}
+
+// getResourceName returns the kind/name of the resource selected by a replacement source or target
+func getResourceName(selector *yaml.Node) string {
+	kind, name := scalarValue(mappingValue(selector, "kind")), scalarValue(mappingValue(selector, "name"))
+	if name == "" {
+		return kind
+	}
+
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+// readYamlDocuments reads every mapping document of a multi-document YAML file, keeping their comments and removing
+// the keys marked with @ignore
+func readYamlDocuments(file string) ([]*yaml.Node, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	documents := make([]*yaml.Node, 0)
+	decoder := yaml.NewDecoder(f)
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		removeIgnored(&document, document.Kind)
+		if len(document.Content) > 0 && document.Content[0].Kind == yaml.MappingNode {
+			documents = append(documents, &document)
+		}
+	}
+
+	return documents, nil
+}
+
+// sequenceItems returns the items of a sequence node, or nothing for any other node
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	return node.Content
+}
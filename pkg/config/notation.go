@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	DefaultKeyNotation     = "default"
+	JsonPathKeyNotation    = "jsonpath"
+	JsonPointerKeyNotation = "jsonpointer"
+	HelmKeyNotation        = "helm"
+	EnvKeyNotation         = "env"
+)
+
+var KeyNotations = []string{DefaultKeyNotation, JsonPathKeyNotation, JsonPointerKeyNotation, HelmKeyNotation, EnvKeyNotation}
+
+var jsonPathIdentifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+var envKeySeparatorRegex = regexp.MustCompile(`[^A-Za-z0-9]+`)
+var helmKeyEscaper = strings.NewReplacer(`\`, `\\`, `.`, `\.`, `,`, `\,`, `=`, `\=`, `[`, `\[`)
+var jsonPointerKeyEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// IsKeyNotation returns whether the notation is one of the supported key path notations.
+func IsKeyNotation(notation string) bool {
+	for _, n := range KeyNotations {
+		if notation == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FormatObjectKey appends an object key to a key path in the given notation, e.g. a.b."c.d" in the default notation,
+// $.a.b['c.d'] as JSONPath, /a/b/c.d as JSON Pointer, a.b.c\.d in Helm --set syntax or A_B_C_D as an environment variable.
+func FormatObjectKey(notation string, prefix string, key string) string {
+	switch notation {
+	case JsonPathKeyNotation:
+		if prefix == "" {
+			prefix = "$"
+		}
+		if jsonPathIdentifierRegex.MatchString(key) {
+			return fmt.Sprintf("%s.%s", prefix, key)
+		}
+		return fmt.Sprintf("%s['%s']", prefix, strings.ReplaceAll(key, "'", `\'`))
+	case JsonPointerKeyNotation:
+		return fmt.Sprintf("%s/%s", prefix, jsonPointerKeyEscaper.Replace(key))
+	case HelmKeyNotation:
+		return joinKeyPath(prefix, ".", helmKeyEscaper.Replace(key))
+	case EnvKeyNotation:
+		return joinKeyPath(prefix, "_", strings.ToUpper(strings.Trim(envKeySeparatorRegex.ReplaceAllString(key, "_"), "_")))
+	default:
+		if strings.Contains(key, ".") || strings.Contains(key, " ") {
+			key = fmt.Sprintf(`"%s"`, key)
+		}
+		return joinKeyPath(prefix, ".", key)
+	}
+}
+
+// FormatListKey appends a list index to a key path in the given notation.
+func FormatListKey(notation string, prefix string, index int) string {
+	switch notation {
+	case JsonPathKeyNotation:
+		if prefix == "" {
+			prefix = "$"
+		}
+		return fmt.Sprintf("%s[%d]", prefix, index)
+	case JsonPointerKeyNotation:
+		return fmt.Sprintf("%s/%d", prefix, index)
+	case EnvKeyNotation:
+		return joinKeyPath(prefix, "_", fmt.Sprint(index))
+	default:
+		return fmt.Sprintf("%s[%d]", prefix, index)
+	}
+}
+
+func joinKeyPath(prefix string, separator string, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + separator + key
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+type openApiDocument struct {
+	Info struct {
+		Title       string `yaml:"title"`
+		Description string `yaml:"description"`
+		Version     string `yaml:"version"`
+	} `yaml:"info"`
+}
+
+// parseOpenApiFiles documents the schemas in the components of OpenAPI 3 documents, or in the definitions of Swagger 2
+// documents. Each schema is placed in its own section and its properties are keyed below the schema name.
+func parseOpenApiFiles(files []string, keyNotation string) (*yaml.Node, map[string]ValueDescription, DocumentMetadata, error) {
+	var metadata DocumentMetadata
+
+	builder := newSchemaValuesBuilder(keyNotation)
+	values := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+	for _, file := range files {
+		document, err := parseConfigFile(file)
+		if err != nil || len(document.Content) == 0 {
+			return nil, nil, metadata, &ParseError{ConfigPath: file, Message: fmt.Sprintf("Error parsing OpenAPI document: %v", err)}
+		}
+
+		var info openApiDocument
+		if err := document.Content[0].Decode(&info); err != nil {
+			return nil, nil, metadata, &ParseError{ConfigPath: file, Message: fmt.Sprintf("Error parsing OpenAPI document: %s", err)}
+		}
+		metadata = mergeDocumentMetadata(metadata, DocumentMetadata{
+			Title:       info.Info.Title,
+			Description: info.Info.Description,
+			Version:     info.Info.Version,
+		})
+
+		root, err := parseYamlSchema(document.Content[0])
+		if err != nil {
+			return nil, nil, metadata, &ParseError{ConfigPath: file, Message: fmt.Sprintf("Error parsing OpenAPI schemas: %s", err)}
+		}
+
+		schemas := root.Components.Schemas
+		if len(schemas) == 0 {
+			schemas = root.Definitions
+		}
+
+		names := make([]string, 0, len(schemas))
+		for name := range schemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			schema := schemas[name]
+			schema.setRoot(root)
+			schema = schema.resolve()
+
+			key := FormatObjectKey(keyNotation, "", name)
+			description := schema.ValueDescription(name)
+			description.Section = name
+			description.SectionDescription = schema.Description
+
+			builder.line++
+			keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name, Line: builder.line, Column: 1}
+			valueNode := builder.propertyValues(schema, key, &description, name, schema.Description)
+
+			builder.descriptions[key] = description
+			values.Content = append(values.Content, keyNode, valueNode)
+		}
+	}
+
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{values}}, builder.descriptions, metadata, nil
+}
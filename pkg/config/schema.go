@@ -0,0 +1,412 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// JsonSchema is the subset of a JSON Schema used as a fallback source of value descriptions
+type JsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Type                 interface{}            `json:"type"`
+	Description          string                 `json:"description"`
+	Default              interface{}            `json:"default"`
+	Enum                 []interface{}          `json:"enum"`
+	Deprecated           bool                   `json:"deprecated"`
+	Example              interface{}            `json:"example"`
+	Examples             []interface{}          `json:"examples"`
+	Minimum              *json.Number           `json:"minimum"`
+	Maximum              *json.Number           `json:"maximum"`
+	MinLength            *json.Number           `json:"minLength"`
+	MaxLength            *json.Number           `json:"maxLength"`
+	Pattern              string                 `json:"pattern"`
+	Format               string                 `json:"format"`
+	Properties           map[string]*JsonSchema `json:"properties"`
+	Required             json.RawMessage        `json:"required"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+	Items                json.RawMessage        `json:"items"`
+	Definitions          map[string]*JsonSchema `json:"definitions"`
+	Defs                 map[string]*JsonSchema `json:"$defs"`
+	AllOf                []*JsonSchema          `json:"allOf"`
+
+	// OpenAPI documents keep their schemas in components
+	Components struct {
+		Schemas map[string]*JsonSchema `json:"schemas"`
+	} `json:"components"`
+
+	root *JsonSchema
+}
+
+var jsonSchemaTypeNames = map[string]string{
+	"array":   "list",
+	"boolean": "bool",
+	"integer": "int",
+	"number":  "float",
+	"object":  "object",
+	"string":  "string",
+}
+
+// ParseJsonSchema reads a JSON Schema document from a file
+func ParseJsonSchema(schemaFile string) (*JsonSchema, error) {
+	contents, err := os.ReadFile(schemaFile)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := parseJsonSchemaContent(contents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JSON schema %s: %s", schemaFile, err)
+	}
+
+	return schema, nil
+}
+
+// parseYamlSchema reads a schema embedded in a YAML document, such as an OpenAPI schema
+func parseYamlSchema(node *yaml.Node) (*JsonSchema, error) {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	contents, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseJsonSchemaContent(contents)
+}
+
+// UnmarshalJSON decodes a schema, accepting the boolean schemas true and false, which allow any value and no value,
+// as schemas without constraints
+func (s *JsonSchema) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.Equal(trimmed, []byte("true")) || bytes.Equal(trimmed, []byte("false")) {
+		*s = JsonSchema{}
+		return nil
+	}
+
+	// Decode into a type without this method, keeping numbers such as defaults and enums as they are written
+	type jsonSchema JsonSchema
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode((*jsonSchema)(s))
+}
+
+func parseJsonSchemaContent(contents []byte) (*JsonSchema, error) {
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.UseNumber()
+
+	var schema JsonSchema
+	if err := decoder.Decode(&schema); err != nil {
+		return nil, err
+	}
+
+	schema.root = &schema
+	return &schema, nil
+}
+
+// getSchemaFile returns the path of the schema-file option, relative to the configuration directory or to the
+// directory of a configuration file
+func getSchemaFile(configPath string) string {
+	schemaFile := viper.GetString("schema-file")
+	if schemaFile == "" || filepath.IsAbs(schemaFile) {
+		return schemaFile
+	}
+
+	if info, err := os.Stat(configPath); err == nil && !info.IsDir() {
+		configPath = filepath.Dir(configPath)
+	}
+
+	return filepath.Join(configPath, schemaFile)
+}
+
+// resolve follows local references such as #/definitions/name or #/$defs/name to the schema they point to
+func (s *JsonSchema) resolve() *JsonSchema {
+	for depth := 0; s != nil && s.Ref != "" && depth < maxIncludeDepth; depth++ {
+		if s.root == nil || !strings.HasPrefix(s.Ref, "#/") {
+			return s
+		}
+
+		var target *JsonSchema
+		path := strings.Split(strings.TrimPrefix(s.Ref, "#/"), "/")
+		if len(path) == 2 && path[0] == "definitions" {
+			target = s.root.Definitions[path[1]]
+		} else if len(path) == 2 && path[0] == "$defs" {
+			target = s.root.Defs[path[1]]
+		} else if len(path) == 3 && path[0] == "components" && path[1] == "schemas" {
+			target = s.root.Components.Schemas[path[2]]
+		}
+
+		if target == nil {
+			return s
+		}
+
+		target.setRoot(s.root)
+		s = target
+	}
+
+	return s
+}
+
+func (s *JsonSchema) setRoot(root *JsonSchema) {
+	if s != nil && s.root == nil {
+		s.root = root
+	}
+}
+
+func (s *JsonSchema) subschema(raw json.RawMessage) *JsonSchema {
+	if !bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+		return nil
+	}
+
+	var child JsonSchema
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&child); err != nil {
+		return nil
+	}
+
+	child.root = s.root
+	return child.resolve()
+}
+
+// Property returns the schema of an object key, falling back to the schema of additional properties
+func (s *JsonSchema) Property(key string) *JsonSchema {
+	s = s.resolve()
+	if s == nil {
+		return nil
+	}
+
+	if property, ok := s.allProperties()[key]; ok && property != nil {
+		property.setRoot(s.root)
+		return property.resolve()
+	}
+
+	return s.subschema(s.AdditionalProperties)
+}
+
+// allProperties returns the properties of the schema, including those of the schemas it combines with allOf
+func (s *JsonSchema) allProperties() map[string]*JsonSchema {
+	properties := make(map[string]*JsonSchema, len(s.Properties))
+	for _, part := range s.AllOf {
+		part.setRoot(s.root)
+		if part = part.resolve(); part != nil {
+			for name, property := range part.allProperties() {
+				properties[name] = property
+			}
+		}
+	}
+
+	for name, property := range s.Properties {
+		properties[name] = property
+	}
+
+	return properties
+}
+
+// Item returns the schema of the items of a list
+func (s *JsonSchema) Item() *JsonSchema {
+	s = s.resolve()
+	if s == nil {
+		return nil
+	}
+
+	return s.subschema(s.Items)
+}
+
+// RequiredProperties returns the names of the required properties of an object schema
+func (s *JsonSchema) RequiredProperties() []string {
+	var required []string
+	if s = s.resolve(); s != nil {
+		// Ignore the boolean form of draft 3 schemas
+		_ = json.Unmarshal(s.Required, &required)
+
+		for _, part := range s.AllOf {
+			part.setRoot(s.root)
+			required = append(required, part.RequiredProperties()...)
+		}
+	}
+
+	return required
+}
+
+// TypeName returns the value type named by the schema, using the type names of the values table
+func (s *JsonSchema) TypeName() string {
+	if s = s.resolve(); s == nil {
+		return ""
+	}
+
+	switch t := s.Type.(type) {
+	case string:
+		return jsonSchemaTypeNames[t]
+	case []interface{}:
+		for _, name := range t {
+			if name, ok := name.(string); ok && name != "null" {
+				return jsonSchemaTypeNames[name]
+			}
+		}
+	}
+
+	// Object schemas often leave out their type
+	if len(s.allProperties()) > 0 {
+		return "object"
+	}
+
+	return ""
+}
+
+// ValueDescription converts the schema of a value into a value description
+func (s *JsonSchema) ValueDescription(key string) ValueDescription {
+	// Properties without a schema, such as those set to null, are not described
+	if s = s.resolve(); s == nil {
+		return ValueDescription{}
+	}
+
+	description := ValueDescription{
+		Description: s.Description,
+		ValueType:   s.TypeName(),
+		Deprecated:  s.Deprecated,
+		Pattern:     s.Pattern,
+		Format:      s.Format,
+		Minimum:     jsonNumberString(s.Minimum),
+		Maximum:     jsonNumberString(s.Maximum),
+		MinLength:   jsonNumberString(s.MinLength),
+		MaxLength:   jsonNumberString(s.MaxLength),
+	}
+
+	for _, value := range s.Enum {
+		description.AllowedValues = append(description.AllowedValues, jsonValueString(value))
+	}
+
+	if s.Default != nil {
+		description.Default = fmt.Sprintf("`%s`", jsonValueString(s.Default))
+	}
+
+	examples := s.Examples
+	if s.Example != nil {
+		examples = append([]interface{}{s.Example}, examples...)
+	}
+
+	for _, example := range examples {
+		if e, err := yaml.Marshal(map[string]interface{}{key: example}); err == nil {
+			description.Example += string(e)
+		}
+	}
+	description.Example = strings.TrimSuffix(description.Example, "\n")
+
+	return description
+}
+
+func jsonNumberString(n *json.Number) string {
+	if n == nil {
+		return ""
+	}
+
+	return n.String()
+}
+
+func jsonValueString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(encoded)
+}
+
+// schemaValuesBuilder converts the properties of object schemas into a values node and value descriptions, so that
+// schemas can be documented by the same pipeline as configuration files
+type schemaValuesBuilder struct {
+	keyNotation  string
+	descriptions map[string]ValueDescription
+	line         int
+
+	// expanding holds the object schemas being converted, so that recursive schemas are only expanded once
+	expanding map[*JsonSchema]bool
+}
+
+func newSchemaValuesBuilder(keyNotation string) *schemaValuesBuilder {
+	return &schemaValuesBuilder{
+		keyNotation:  keyNotation,
+		descriptions: make(map[string]ValueDescription),
+		expanding:    make(map[*JsonSchema]bool),
+	}
+}
+
+// objectValues returns a mapping node with a key for every property of the schema, in alphabetical order. Every
+// property is described, and placed in the given section when it is set.
+func (b *schemaValuesBuilder) objectValues(schema *JsonSchema, prefix string, section string, sectionDescription string) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	if schema = schema.resolve(); schema == nil || b.expanding[schema] {
+		return node
+	}
+
+	b.expanding[schema] = true
+	defer delete(b.expanding, schema)
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.allProperties() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	required := schema.RequiredProperties()
+	for _, name := range names {
+		property := schema.Property(name)
+		key := FormatObjectKey(b.keyNotation, prefix, name)
+
+		description := property.ValueDescription(name)
+		description.Required = slices.Contains(required, name)
+		description.Section = section
+		description.SectionDescription = sectionDescription
+
+		b.line++
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name, Line: b.line, Column: 1}
+		valueNode := b.propertyValues(property, key, &description, section, sectionDescription)
+
+		b.descriptions[key] = description
+		node.Content = append(node.Content, keyNode, valueNode)
+	}
+
+	return node
+}
+
+// propertyValues returns the value node of a property, which is its default when the schema has one. Objects and lists
+// of objects without a default are documented with their own properties.
+func (b *schemaValuesBuilder) propertyValues(schema *JsonSchema, key string, description *ValueDescription, section string, sectionDescription string) *yaml.Node {
+	if schema != nil && schema.Default != nil {
+		var node yaml.Node
+		if err := node.Encode(schema.Default); err == nil {
+			return &node
+		}
+	}
+
+	switch schema.TypeName() {
+	case "object":
+		description.Default = "`{}`"
+		return b.objectValues(schema, key, section, sectionDescription)
+	case "list":
+		description.Default = "`[]`"
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if item := schema.Item(); item != nil && item.TypeName() == "object" {
+			node.Content = append(node.Content, b.objectValues(item, FormatListKey(b.keyNotation, key, 0), section, sectionDescription))
+		}
+		return node
+	}
+
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+}
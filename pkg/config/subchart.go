@@ -0,0 +1,239 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/blakyaks/yaml-docs/pkg/util"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	AllSubchartValues      = "all"
+	OverrideSubchartValues = "overrides"
+	NoSubchartValues       = "none"
+)
+
+var SubchartValuesModes = []string{AllSubchartValues, OverrideSubchartValues, NoSubchartValues}
+
+// subchart is an unpacked chart in the charts directory, along with the key its values are set under in the parent
+type subchart struct {
+	key       string
+	directory string
+}
+
+// applySubchartValues documents the values of the subcharts of a Helm chart under the key of each dependency, which is
+// its alias when it has one. With the overrides mode, only the values set by the parent are documented, using the
+// descriptions of the subchart when the parent does not describe them.
+func applySubchartValues(chartDirectory string, chart *ChartMetadata, values *yaml.Node, descriptions map[string]ValueDescription, documentationParsingConfig DocumentationParsingConfig) error {
+	mode := viper.GetString("subchart-values")
+	if mode == "" {
+		mode = AllSubchartValues
+	} else if !slices.Contains(SubchartValuesModes, mode) {
+		log.Warnf("Invalid subchart values mode provided %s, defaulting to %s", mode, AllSubchartValues)
+		mode = AllSubchartValues
+	}
+	if mode == NoSubchartValues || values == nil || len(values.Content) == 0 {
+		return nil
+	}
+
+	lintingConfig := documentationParsingConfig
+	lintingConfig.StrictMode = false
+	keyNotation := documentationParsingConfig.KeyNotation
+
+	line := maxLine(values)
+	for _, sub := range findSubcharts(chartDirectory, chart) {
+		valuesFile := filepath.Join(sub.directory, "values.yaml")
+		if _, err := os.Stat(valuesFile); err != nil {
+			continue
+		}
+
+		subValues, _, err := parseValues([]string{valuesFile})
+		if err != nil {
+			return err
+		}
+		if len(subValues.Content) == 0 || subValues.Content[0].Kind != yaml.MappingNode {
+			continue
+		}
+
+		subDescriptions, err := parseValueDescriptions([]string{valuesFile}, subValues, lintingConfig)
+		if err != nil {
+			return err
+		}
+
+		// Subchart values are placed after the values of the parent when sorted in file order
+		subRoot := subValues.Content[0]
+		shiftLines(subRoot, line)
+		line = maxLine(subRoot)
+
+		parent, index := findMappingKey(values, sub.key)
+		target := subRoot
+		switch {
+		case index >= 0 && mode == OverrideSubchartValues:
+			target = parent.Content[index+1]
+		case index >= 0:
+			target = util.MergeYAMLNodes(subRoot, parent.Content[index+1]).Content[0]
+			parent.Content[index+1] = target
+		case mode == OverrideSubchartValues:
+			continue
+		default:
+			root := values.Content[0]
+			root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: sub.key, Line: subRoot.Line, Column: 1}, subRoot)
+		}
+
+		walkSubchartKeys(target, subRoot, "", FormatObjectKey(keyNotation, "", sub.key), keyNotation, func(subKey string, key string, keyNode *yaml.Node, subKeyNode *yaml.Node) {
+			if _, ok := descriptions[key]; ok {
+				return
+			}
+
+			if description, ok := subDescriptions[subKey]; ok {
+				descriptions[key] = description
+			} else if subKeyNode != keyNode && !strings.Contains(keyNode.HeadComment, PrefixComment) {
+				if description := commentDescription(subKeyNode); description.Description != "" {
+					descriptions[key] = description
+				}
+			}
+		})
+	}
+
+	return nil
+}
+
+// findSubcharts returns the unpacked subcharts in the charts directory, under the alias or name of every dependency
+// on them. Subcharts that are not listed as dependencies are keyed by their chart name.
+func findSubcharts(chartDirectory string, chart *ChartMetadata) []subchart {
+	entries, err := os.ReadDir(filepath.Join(chartDirectory, "charts"))
+	if err != nil {
+		return nil
+	}
+
+	subcharts := make([]subchart, 0)
+	for _, entry := range entries {
+		directory := filepath.Join(chartDirectory, "charts", entry.Name())
+		if !entry.IsDir() {
+			log.Debugf("Skipping packaged subchart %s", directory)
+			continue
+		}
+
+		subMetadata, err := ParseChartFile(directory)
+		if err != nil || subMetadata == nil {
+			log.Debugf("Skipping %s, which has no readable Chart.yaml", directory)
+			continue
+		}
+
+		keys := make([]string, 0)
+		for _, dependency := range chart.Dependencies {
+			if dependency.Name != subMetadata.Name {
+				continue
+			}
+			if dependency.Alias != "" {
+				keys = append(keys, dependency.Alias)
+			} else {
+				keys = append(keys, dependency.Name)
+			}
+		}
+		if len(keys) == 0 {
+			keys = append(keys, subMetadata.Name)
+		}
+
+		for _, key := range keys {
+			subcharts = append(subcharts, subchart{key: key, directory: directory})
+		}
+	}
+
+	return subcharts
+}
+
+// removeSubchartFiles removes the files of the charts directory from the configuration files, as subchart values are
+// documented under the key of their dependency
+func removeSubchartFiles(configPath string, files []string) []string {
+	chartsDirectory := filepath.Join(getChartDirectory(configPath), "charts") + string(filepath.Separator)
+
+	remaining := make([]string, 0, len(files))
+	for _, file := range files {
+		if !strings.HasPrefix(filepath.Clean(file), chartsDirectory) {
+			remaining = append(remaining, file)
+		}
+	}
+
+	return remaining
+}
+
+// walkSubchartKeys visits every key below the node along with the key of the same value in the subchart, and the key
+// node at the same path in the subchart values when there is one
+func walkSubchartKeys(node *yaml.Node, subNode *yaml.Node, subPrefix string, prefix string, keyNotation string, visit func(subKey string, key string, keyNode *yaml.Node, subKeyNode *yaml.Node)) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, value := node.Content[i], node.Content[i+1]
+			subKey := FormatObjectKey(keyNotation, subPrefix, keyNode.Value)
+			key := FormatObjectKey(keyNotation, prefix, keyNode.Value)
+
+			var subKeyNode, subValue *yaml.Node
+			if subNode != nil && subNode.Kind == yaml.MappingNode {
+				if index := mappingKeyIndex(subNode, keyNode.Value); index >= 0 {
+					subKeyNode, subValue = subNode.Content[index], subNode.Content[index+1]
+				}
+			}
+
+			if subKeyNode != nil {
+				visit(subKey, key, keyNode, subKeyNode)
+			}
+			walkSubchartKeys(value, subValue, subKey, key, keyNotation, visit)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			var subItem *yaml.Node
+			if subNode != nil && subNode.Kind == yaml.SequenceNode && i < len(subNode.Content) {
+				subItem = subNode.Content[i]
+			}
+			walkSubchartKeys(item, subItem, FormatListKey(keyNotation, subPrefix, i), FormatListKey(keyNotation, prefix, i), keyNotation, visit)
+		}
+	}
+}
+
+// findMappingKey returns the mapping of a document holding the key, along with the index of the key in its content,
+// or -1 when no mapping holds it
+func findMappingKey(document *yaml.Node, key string) (*yaml.Node, int) {
+	for _, mapping := range document.Content {
+		if index := mappingKeyIndex(mapping, key); index >= 0 {
+			return mapping, index
+		}
+	}
+
+	return nil, -1
+}
+
+func mappingKeyIndex(node *yaml.Node, key string) int {
+	if node.Kind != yaml.MappingNode {
+		return -1
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func maxLine(node *yaml.Node) int {
+	line := node.Line
+	for _, child := range node.Content {
+		line = max(line, maxLine(child))
+	}
+
+	return line
+}
+
+func shiftLines(node *yaml.Node, offset int) {
+	node.Line += offset
+	for _, child := range node.Content {
+		shiftLines(child, offset)
+	}
+}
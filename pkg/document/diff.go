@@ -0,0 +1,271 @@
+package document
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+)
+
+// ValueChange describes a documented value whose default or type differs between two versions of a configuration
+type ValueChange struct {
+	Key string `json:"key"`
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// ValueRename describes a removed key that was matched to an added key with the same content
+type ValueRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ValuesDiff is the semantic difference between two versions of a documented configuration
+type ValuesDiff struct {
+	Added           []ValueInfo   `json:"added"`
+	Removed         []ValueInfo   `json:"removed"`
+	Renamed         []ValueRename `json:"renamed"`
+	DefaultsChanged []ValueChange `json:"defaultsChanged"`
+	TypesChanged    []ValueChange `json:"typesChanged"`
+	NewlyRequired   []ValueInfo   `json:"newlyRequired"`
+	NewlyDeprecated []ValueInfo   `json:"newlyDeprecated"`
+}
+
+// IsEmpty returns true when no configuration changes were found
+func (d ValuesDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Renamed) == 0 && len(d.DefaultsChanged) == 0 &&
+		len(d.TypesChanged) == 0 && len(d.NewlyRequired) == 0 && len(d.NewlyDeprecated) == 0
+}
+
+// DiffValues compares the documented values of two versions of a configuration
+func DiffValues(oldInfo config.DocumentationInfo, newInfo config.DocumentationInfo) (ValuesDiff, error) {
+	oldValues, err := ListValues(oldInfo, ValueFilter{})
+	if err != nil {
+		return ValuesDiff{}, err
+	}
+
+	newValues, err := ListValues(newInfo, ValueFilter{})
+	if err != nil {
+		return ValuesDiff{}, err
+	}
+
+	return diffValueInfos(oldValues, newValues), nil
+}
+
+func diffValueInfos(oldValues []ValueInfo, newValues []ValueInfo) ValuesDiff {
+	diff := ValuesDiff{
+		Added:           []ValueInfo{},
+		Removed:         []ValueInfo{},
+		Renamed:         []ValueRename{},
+		DefaultsChanged: []ValueChange{},
+		TypesChanged:    []ValueChange{},
+		NewlyRequired:   []ValueInfo{},
+		NewlyDeprecated: []ValueInfo{},
+	}
+
+	oldByKey := make(map[string]ValueInfo, len(oldValues))
+	for _, value := range oldValues {
+		oldByKey[value.Key] = value
+	}
+
+	newByKey := make(map[string]ValueInfo, len(newValues))
+	for _, value := range newValues {
+		newByKey[value.Key] = value
+	}
+
+	for _, newValue := range newValues {
+		oldValue, ok := oldByKey[newValue.Key]
+		if !ok {
+			diff.Added = append(diff.Added, newValue)
+			continue
+		}
+
+		if oldValue.Default != newValue.Default {
+			diff.DefaultsChanged = append(diff.DefaultsChanged, ValueChange{Key: newValue.Key, Old: oldValue.Default, New: newValue.Default})
+		}
+		if oldValue.Type != newValue.Type {
+			diff.TypesChanged = append(diff.TypesChanged, ValueChange{Key: newValue.Key, Old: oldValue.Type, New: newValue.Type})
+		}
+		if !oldValue.Required && newValue.Required {
+			diff.NewlyRequired = append(diff.NewlyRequired, newValue)
+		}
+		if !oldValue.Deprecated && newValue.Deprecated {
+			diff.NewlyDeprecated = append(diff.NewlyDeprecated, newValue)
+		}
+	}
+
+	for _, oldValue := range oldValues {
+		if _, ok := newByKey[oldValue.Key]; !ok {
+			diff.Removed = append(diff.Removed, oldValue)
+		}
+	}
+
+	diff.Added, diff.Removed, diff.Renamed = matchRenamedValues(diff.Added, diff.Removed)
+
+	return diff
+}
+
+// matchRenamedValues pairs removed and added values that share their type and default, and either share a non-empty
+// description or share their leaf key name without being described differently. Values are only paired when they are
+// the single candidate of each other, and paired values are reported as renames instead of an addition and a removal.
+func matchRenamedValues(added []ValueInfo, removed []ValueInfo) ([]ValueInfo, []ValueInfo, []ValueRename) {
+	renamed := make([]ValueRename, 0)
+	remainingAdded := make([]ValueInfo, 0, len(added))
+	matchedRemoved := make(map[int]bool)
+
+	candidates := make([][]int, len(added))
+	candidateCounts := make([]int, len(removed))
+	for i, addedValue := range added {
+		for j, removedValue := range removed {
+			if isRenamedValue(removedValue, addedValue) {
+				candidates[i] = append(candidates[i], j)
+				candidateCounts[j]++
+			}
+		}
+	}
+
+	for i, addedValue := range added {
+		if len(candidates[i]) != 1 || candidateCounts[candidates[i][0]] != 1 {
+			remainingAdded = append(remainingAdded, addedValue)
+			continue
+		}
+
+		match := candidates[i][0]
+		matchedRemoved[match] = true
+		renamed = append(renamed, ValueRename{From: removed[match].Key, To: addedValue.Key})
+	}
+
+	remainingRemoved := make([]ValueInfo, 0, len(removed))
+	for i, removedValue := range removed {
+		if !matchedRemoved[i] {
+			remainingRemoved = append(remainingRemoved, removedValue)
+		}
+	}
+
+	sort.Slice(renamed, func(i, j int) bool {
+		return renamed[i].To < renamed[j].To
+	})
+
+	return remainingAdded, remainingRemoved, renamed
+}
+
+func isRenamedValue(removedValue ValueInfo, addedValue ValueInfo) bool {
+	if removedValue.Type != addedValue.Type || removedValue.Default != addedValue.Default {
+		return false
+	}
+
+	if removedValue.Description != "" && addedValue.Description != "" {
+		return removedValue.Description == addedValue.Description
+	}
+
+	return lastKeySegment(removedValue.Key) == lastKeySegment(addedValue.Key)
+}
+
+func lastKeySegment(key string) string {
+	// Quoted segments may contain dots, so take the full quoted segment
+	if strings.HasSuffix(key, `"`) {
+		if quoteIdx := strings.LastIndex(key[:len(key)-1], `"`); quoteIdx >= 0 {
+			return key[quoteIdx:]
+		}
+	}
+
+	idx := strings.LastIndexAny(key, ".[")
+	if idx < 0 {
+		return key
+	}
+	if key[idx] == '.' {
+		idx++
+	}
+
+	return key[idx:]
+}
+
+// Markdown renders the diff as a set of markdown tables suitable for release notes
+func (d ValuesDiff) Markdown() string {
+	s := strings.Builder{}
+	s.WriteString("## Configuration Changes\n")
+
+	if d.IsEmpty() {
+		s.WriteString("\nNo configuration changes.\n")
+		return s.String()
+	}
+
+	d.writeMarkdownTables(&s)
+
+	return s.String()
+}
+
+// writeMarkdownTables writes a level three heading and table for each kind of change found
+func (d ValuesDiff) writeMarkdownTables(s *strings.Builder) {
+	if len(d.Added) > 0 {
+		s.WriteString("\n### Added\n\n")
+		s.WriteString("| Key | Type | Default | Description |\n")
+		s.WriteString("|-----|------|---------|-------------|\n")
+		for _, value := range d.Added {
+			s.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", value.Key, value.Type, markdownCode(value.Default), markdownCell(value.Description)))
+		}
+	}
+
+	if len(d.Removed) > 0 {
+		s.WriteString("\n### Removed\n\n")
+		s.WriteString("| Key | Type | Default |\n")
+		s.WriteString("|-----|------|---------|\n")
+		for _, value := range d.Removed {
+			s.WriteString(fmt.Sprintf("| %s | %s | %s |\n", value.Key, value.Type, markdownCode(value.Default)))
+		}
+	}
+
+	if len(d.Renamed) > 0 {
+		s.WriteString("\n### Renamed\n\n")
+		s.WriteString("| Old Key | New Key |\n")
+		s.WriteString("|---------|---------|\n")
+		for _, rename := range d.Renamed {
+			s.WriteString(fmt.Sprintf("| %s | %s |\n", rename.From, rename.To))
+		}
+	}
+
+	if len(d.DefaultsChanged) > 0 {
+		s.WriteString("\n### Changed Defaults\n\n")
+		s.WriteString("| Key | Old Default | New Default |\n")
+		s.WriteString("|-----|-------------|-------------|\n")
+		for _, change := range d.DefaultsChanged {
+			s.WriteString(fmt.Sprintf("| %s | %s | %s |\n", change.Key, markdownCode(change.Old), markdownCode(change.New)))
+		}
+	}
+
+	if len(d.TypesChanged) > 0 {
+		s.WriteString("\n### Changed Types\n\n")
+		s.WriteString("| Key | Old Type | New Type |\n")
+		s.WriteString("|-----|----------|----------|\n")
+		for _, change := range d.TypesChanged {
+			s.WriteString(fmt.Sprintf("| %s | %s | %s |\n", change.Key, change.Old, change.New))
+		}
+	}
+
+	if len(d.NewlyRequired) > 0 {
+		s.WriteString("\n### Newly Required\n\n")
+		for _, value := range d.NewlyRequired {
+			s.WriteString(fmt.Sprintf("- `%s`\n", value.Key))
+		}
+	}
+
+	if len(d.NewlyDeprecated) > 0 {
+		s.WriteString("\n### Newly Deprecated\n\n")
+		for _, value := range d.NewlyDeprecated {
+			s.WriteString(fmt.Sprintf("- `%s`\n", value.Key))
+		}
+	}
+}
+
+func markdownCode(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("`%s`", value)
+}
+
+func markdownCell(value string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(value, "|", "\\|"), "\n", " ")
+}
@@ -0,0 +1,112 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func getDiffTestInfo(yamlValues string) config.DocumentationInfo {
+	return config.DocumentationInfo{
+		Values:             &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{parseYamlValues(yamlValues)}},
+		ValuesDescriptions: make(map[string]config.ValueDescription),
+	}
+}
+
+func TestDiffValues(t *testing.T) {
+	oldInfo := getDiffTestInfo(`
+# -- Number of replicas
+replicas: 1
+# -- Log verbosity
+logLevel: info
+# -- Listen port
+port: "8080"
+# -- Removed setting
+legacy: true
+service:
+  # -- Type of service
+  type: ClusterIP
+`)
+	newInfo := getDiffTestInfo(`
+# -- @required Number of replicas
+replicas: 3
+# -- @deprecated Log verbosity
+logLevel: info
+# -- Listen port
+port: 8080
+# -- Metrics toggle
+metrics: false
+server:
+  # -- Type of service
+  type: ClusterIP
+`)
+
+	diff, err := DiffValues(oldInfo, newInfo)
+
+	require.NoError(t, err)
+	assert.False(t, diff.IsEmpty())
+	assert.Equal(t, []string{"metrics"}, valueInfoKeys(diff.Added))
+	assert.Equal(t, []string{"legacy"}, valueInfoKeys(diff.Removed))
+	assert.Equal(t, []ValueRename{{From: "service.type", To: "server.type"}}, diff.Renamed)
+	assert.Equal(t, []ValueChange{{Key: "replicas", Old: "1", New: "3"}}, diff.DefaultsChanged)
+	assert.Equal(t, []ValueChange{{Key: "port", Old: "string", New: "int"}}, diff.TypesChanged)
+	assert.Equal(t, []string{"replicas"}, valueInfoKeys(diff.NewlyRequired))
+	assert.Equal(t, []string{"logLevel"}, valueInfoKeys(diff.NewlyDeprecated))
+
+	markdown := diff.Markdown()
+	assert.Contains(t, markdown, "| service.type | server.type |")
+	assert.Contains(t, markdown, "| replicas | `1` | `3` |")
+}
+
+func TestDiffValuesUnrelatedValues(t *testing.T) {
+	oldInfo := getDiffTestInfo(`
+metrics:
+  # -- Enable metrics
+  enabled: true
+cache:
+  # -- Cache size
+  size: 10
+`)
+	newInfo := getDiffTestInfo(`
+tracing:
+  # -- Enable tracing
+  enabled: true
+memory:
+  # -- Cache size
+  size: 10
+disk:
+  # -- Cache size
+  size: 10
+`)
+
+	diff, err := DiffValues(oldInfo, newInfo)
+
+	require.NoError(t, err)
+	assert.Empty(t, diff.Renamed)
+	assert.Equal(t, []string{"disk.size", "memory.size", "tracing.enabled"}, valueInfoKeys(diff.Added))
+	assert.Equal(t, []string{"cache.size", "metrics.enabled"}, valueInfoKeys(diff.Removed))
+}
+
+func TestDiffValuesUnchanged(t *testing.T) {
+	info := getDiffTestInfo(`
+# -- Number of replicas
+replicas: 1
+`)
+
+	diff, err := DiffValues(info, info)
+
+	require.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+	assert.Contains(t, diff.Markdown(), "No configuration changes.")
+}
+
+func valueInfoKeys(values []ValueInfo) []string {
+	keys := make([]string, 0, len(values))
+	for _, value := range values {
+		keys = append(keys, value.Key)
+	}
+	return keys
+}
@@ -0,0 +1,120 @@
+package document
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/blakyaks/yaml-docs/pkg/util"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ViperEnvConvention  = "viper"
+	SpringEnvConvention = "spring"
+)
+
+var envVarSegmentRegex = regexp.MustCompile(`[^A-Za-z0-9]+`)
+var envExampleUnquotedRegex = regexp.MustCompile(`^[A-Za-z0-9_./:@+-]*$`)
+
+// applyEnvVarsToValueRows sets the name of the environment variable that overrides each value, following the
+// env-prefix, env-separator and env-convention options
+func applyEnvVarsToValueRows(valueRows []valueRow, document *yaml.Node) {
+	envVars := make(map[string]string)
+	walkDocumentValueKeys(document, func(key string, segments []keySegment, _ *yaml.Node, _ *yaml.Node) {
+		envVars[key] = getEnvVarName(segments)
+	})
+
+	for i := range valueRows {
+		valueRows[i].EnvVar = envVars[valueRows[i].Key]
+	}
+}
+
+// getEnvVarName builds the environment variable name for the key path segments. With the viper convention, characters
+// that are not valid in an environment variable name are replaced with underscores, as done by a "." and "-" key
+// replacer with AutomaticEnv. With the spring convention, dashes are removed as done by Spring Boot relaxed binding.
+func getEnvVarName(segments []keySegment) string {
+	separator := viper.GetString("env-separator")
+	if separator == "" {
+		separator = "_"
+	}
+
+	parts := make([]string, 0, len(segments)+1)
+	if prefix := viper.GetString("env-prefix"); prefix != "" {
+		parts = append(parts, strings.ToUpper(prefix))
+	}
+
+	for _, pathSegment := range segments {
+		segment := pathSegment.String()
+		if viper.GetString("env-convention") == SpringEnvConvention {
+			segment = strings.ReplaceAll(segment, "-", "")
+		}
+		segment = strings.Trim(envVarSegmentRegex.ReplaceAllString(segment, "_"), "_")
+		parts = append(parts, strings.ToUpper(segment))
+	}
+
+	return strings.Join(parts, separator)
+}
+
+// getEnvExample renders an example environment file with the default of every visible scalar value, preceded by its
+// description. Sensitive defaults are left empty.
+func getEnvExample(valueRows []valueRow) string {
+	s := strings.Builder{}
+
+	for _, row := range valueRows {
+		if row.Hidden || row.EnvVar == "" {
+			continue
+		}
+
+		value := ""
+		switch v := row.value.(type) {
+		case map[string]interface{}, []interface{}:
+			continue
+		case nil:
+		default:
+			if v != maskedValue {
+				value = fmt.Sprint(v)
+			}
+		}
+
+		if !envExampleUnquotedRegex.MatchString(value) {
+			value = strconv.Quote(value)
+		}
+
+		description := row.Description
+		if description == "" {
+			description = row.AutoDescription
+		}
+
+		if s.Len() > 0 {
+			s.WriteString("\n")
+		}
+		if description != "" {
+			s.WriteString("# " + strings.ReplaceAll(description, "\n", "\n# ") + "\n")
+		}
+		s.WriteString(fmt.Sprintf("%s=%s\n", row.EnvVar, value))
+	}
+
+	return s.String()
+}
+
+// printEnvExample writes the example environment file for the value rows to the env-file, or to stdout on a dry run
+func printEnvExample(valueRows []valueRow, configPath string, dryRun bool) {
+	envFile := viper.GetString("env-file")
+	if strings.Contains(envFile, "%s") {
+		envFile = fmt.Sprintf(envFile, util.GetBaseFilename(configPath))
+	}
+
+	if dryRun {
+		fmt.Print(getEnvExample(valueRows))
+		return
+	}
+
+	if err := os.WriteFile(envFile, []byte(getEnvExample(valueRows)), 0644); err != nil {
+		log.Warnf("Error writing environment file %s: %s", envFile, err)
+	}
+}
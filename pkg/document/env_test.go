@@ -0,0 +1,88 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func getEnvTestRows(t *testing.T) []valueRow {
+	configValues := parseYamlValues(`
+# -- Address the server listens on
+listen-address: 0.0.0.0:8080
+database:
+  # -- Database password
+  password: hunter2
+  # -- Connection options
+  options: "sslmode=disable connect_timeout=10"
+# -- Extra volumes
+extraVolumes:
+  - name: config
+`)
+
+	valueRows, err := getValueRows(config.DocumentationInfo{
+		Values:             &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{configValues}},
+		ValuesDescriptions: make(map[string]config.ValueDescription),
+	})
+	require.NoError(t, err)
+
+	return valueRows
+}
+
+func TestEnvVars(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("env-prefix", "myapp")
+
+	valueRows := getEnvTestRows(t)
+	require.Len(t, valueRows, 4)
+	assert.Equal(t, "MYAPP_DATABASE_OPTIONS", valueRows[0].EnvVar)
+	assert.Equal(t, "MYAPP_DATABASE_PASSWORD", valueRows[1].EnvVar)
+	assert.Equal(t, "MYAPP_EXTRAVOLUMES", valueRows[2].EnvVar)
+	assert.Equal(t, "MYAPP_LISTEN_ADDRESS", valueRows[3].EnvVar)
+
+	viper.Set("env-convention", SpringEnvConvention)
+	viper.Set("env-separator", "__")
+
+	valueRows = getEnvTestRows(t)
+	assert.Equal(t, "MYAPP__DATABASE__OPTIONS", valueRows[0].EnvVar)
+	assert.Equal(t, "MYAPP__LISTENADDRESS", valueRows[3].EnvVar)
+}
+
+func TestEnvExample(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("sensitive-key-regex", []string{"(?i).*password.*"})
+
+	expected := `# Connection options
+DATABASE_OPTIONS="sslmode=disable connect_timeout=10"
+
+# Database password
+DATABASE_PASSWORD=
+
+# Address the server listens on
+LISTEN_ADDRESS=0.0.0.0:8080
+`
+
+	assert.Equal(t, expected, getEnvExample(getEnvTestRows(t)))
+}
+
+func TestEnvVarsMultipleFiles(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	valueRows, err := getValueRows(config.DocumentationInfo{
+		Values: &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{
+			parseYamlValues("# -- Set in the first file\na: 1\n"),
+			parseYamlValues("# -- Set in the second file\nb: 2\n"),
+		}},
+		ValuesDescriptions: make(map[string]config.ValueDescription),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, valueRows, 2)
+	assert.Equal(t, "A", valueRows[0].EnvVar)
+	assert.Equal(t, "B", valueRows[1].EnvVar)
+	assert.Equal(t, "# Set in the first file\nA=1\n\n# Set in the second file\nB=2\n", getEnvExample(valueRows))
+}
@@ -0,0 +1,75 @@
+package document
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// getPathFilters compiles the glob and regex path filters of a kind, e.g. include-path and include-path-regex. Globs
+// match a key path and every key below it, where * matches any characters and ? matches a single character, while
+// regexes match any part of a key path.
+func getPathFilters(kind string) []*regexp.Regexp {
+	filters := make([]*regexp.Regexp, 0)
+
+	separators := `.\[`
+	switch viper.GetString("key-notation") {
+	case config.JsonPointerKeyNotation:
+		separators = `/`
+	case config.EnvKeyNotation:
+		separators = `_`
+	}
+
+	for _, glob := range viper.GetStringSlice(kind) {
+		pattern := regexp.QuoteMeta(glob)
+		pattern = strings.ReplaceAll(pattern, `\*`, ".*")
+		pattern = strings.ReplaceAll(pattern, `\?`, ".")
+		filters = append(filters, regexp.MustCompile("^"+pattern+"(?:$|["+separators+"])"))
+	}
+
+	for _, item := range viper.GetStringSlice(kind + "-regex") {
+		regex, err := regexp.Compile(item)
+		if err != nil {
+			log.Warnf("Invalid %s regex %s: %s", kind, item, err)
+			continue
+		}
+		filters = append(filters, regex)
+	}
+
+	return filters
+}
+
+func matchesPathFilter(key string, filters []*regexp.Regexp) bool {
+	for _, filter := range filters {
+		if filter.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filterValueRowsByPath keeps the rows matching any include-path filter, when there are some, and removes the rows
+// matching any exclude-path filter
+func filterValueRowsByPath(valueRows []valueRow) []valueRow {
+	includes, excludes := getPathFilters("include-path"), getPathFilters("exclude-path")
+	if len(includes) == 0 && len(excludes) == 0 {
+		return valueRows
+	}
+
+	filtered := make([]valueRow, 0, len(valueRows))
+	for _, row := range valueRows {
+		if len(includes) > 0 && !matchesPathFilter(row.Key, includes) {
+			continue
+		}
+		if matchesPathFilter(row.Key, excludes) {
+			continue
+		}
+		filtered = append(filtered, row)
+	}
+
+	return filtered
+}
@@ -0,0 +1,89 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func getFilterTestKeys(t *testing.T) []string {
+	configValues := parseYamlValues(`
+# -- Number of replicas
+replicaCount: 1
+ingress:
+  # -- Enable the ingress
+  enabled: false
+  # -- Ingress annotations
+  annotations: {}
+  # -- Ingress TLS configuration
+  tls: []
+ingressClass:
+  # -- Name of the ingress class
+  name: nginx
+service:
+  # -- Service port
+  port: 80
+  # -- Service annotations
+  annotations: {}
+`)
+
+	valueRows, err := getValueRows(config.DocumentationInfo{
+		Values:             &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{configValues}},
+		ValuesDescriptions: make(map[string]config.ValueDescription),
+	})
+	require.NoError(t, err)
+
+	keys := make([]string, 0, len(valueRows))
+	for _, row := range valueRows {
+		keys = append(keys, row.Key)
+	}
+
+	return keys
+}
+
+func TestIncludePath(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	viper.Set("include-path", []string{"ingress"})
+	assert.Equal(t, []string{"ingress.annotations", "ingress.enabled", "ingress.tls"}, getFilterTestKeys(t))
+
+	viper.Set("include-path", []string{"ingress*"})
+	assert.Equal(t, []string{"ingress.annotations", "ingress.enabled", "ingress.tls", "ingressClass.name"}, getFilterTestKeys(t))
+
+	viper.Set("include-path", []string{"*.annotations", "replicaCount"})
+	assert.Equal(t, []string{"ingress.annotations", "replicaCount", "service.annotations"}, getFilterTestKeys(t))
+}
+
+func TestExcludePath(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	viper.Set("include-path", []string{"ingress", "service"})
+	viper.Set("exclude-path", []string{"ingress.tls"})
+	assert.Equal(t, []string{"ingress.annotations", "ingress.enabled", "service.annotations", "service.port"}, getFilterTestKeys(t))
+
+	viper.Set("include-path", []string{})
+	viper.Set("exclude-path", []string{"ingress", "service"})
+	assert.Equal(t, []string{"ingressClass.name", "replicaCount"}, getFilterTestKeys(t))
+}
+
+func TestPathRegex(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	viper.Set("exclude-path-regex", []string{`\.annotations$`, "["})
+	assert.Equal(t, []string{"ingress.enabled", "ingress.tls", "ingressClass.name", "replicaCount", "service.port"}, getFilterTestKeys(t))
+
+	viper.Set("include-path-regex", []string{"^service"})
+	assert.Equal(t, []string{"service.port"}, getFilterTestKeys(t))
+}
+
+func TestPathFilterKeyNotation(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("key-notation", config.JsonPointerKeyNotation)
+
+	viper.Set("include-path", []string{"/ingress"})
+	assert.Equal(t, []string{"/ingress/annotations", "/ingress/enabled", "/ingress/tls"}, getFilterTestKeys(t))
+}
@@ -0,0 +1,133 @@
+package document
+
+import (
+	"encoding/base64"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var quantityRegex = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)(Ki|Mi|Gi|Ti|Pi|Ei|m|k|M|G|T|P|E)?$`)
+var semverRegex = regexp.MustCompile(`^v?\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+// yamlTimestampRegex matches the YAML timestamps that are not dates, which allow a space instead of the T separator, a
+// lowercase separator and a space before a time zone of hours only, e.g. 2001-12-14 21:59:43.10 -5
+var yamlTimestampRegex = regexp.MustCompile(`^\d{4}-\d{1,2}-\d{1,2}(?:[Tt]|[ \t]+)\d{1,2}:\d{2}:\d{2}(?:\.\d*)?(?:[ \t]*(?:Z|[-+]\d{1,2}(?::\d{2})?))?$`)
+var hostnameRegex = regexp.MustCompile(`^(?i)[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+
+// formatValidators check string values against the formats that can be named by the @format annotation
+var formatValidators = map[string]func(string) bool{
+	"duration": func(s string) bool {
+		_, err := time.ParseDuration(s)
+		return err == nil
+	},
+	"hostname": func(s string) bool {
+		return len(s) <= 253 && hostnameRegex.MatchString(s)
+	},
+	"cidr": func(s string) bool {
+		_, _, err := net.ParseCIDR(s)
+		return err == nil
+	},
+	"uri": func(s string) bool {
+		u, err := url.Parse(s)
+		return err == nil && u.Scheme != ""
+	},
+	"ip": func(s string) bool {
+		return net.ParseIP(s) != nil
+	},
+	"ipv4": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() != nil
+	},
+	"ipv6": func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && ip.To4() == nil
+	},
+	"email": func(s string) bool {
+		_, err := mail.ParseAddress(s)
+		return err == nil
+	},
+	"date": func(s string) bool {
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	},
+	"date-time": func(s string) bool {
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil || yamlTimestampRegex.MatchString(s)
+	},
+	"quantity": func(s string) bool {
+		return quantityRegex.MatchString(s)
+	},
+	"semver": func(s string) bool {
+		return semverRegex.MatchString(s)
+	},
+	"base64": func(s string) bool {
+		_, err := base64.StdEncoding.DecodeString(s)
+		return err == nil
+	},
+}
+
+// detectedFormats lists the formats inferred for string values, in the order they are tried. Broad formats such as
+// hostname are left out as most plain strings would match them.
+var detectedFormats = []string{"date-time", "date", "duration", "quantity", "semver", "cidr", "ipv4", "ipv6", "uri", "base64"}
+
+// getTimestampFormat returns the format of a scalar tagged !!timestamp, which is a date when it has no time
+func getTimestampFormat(s string) string {
+	if yamlTimestampRegex.MatchString(s) {
+		return "date-time"
+	}
+
+	return "date"
+}
+
+// detectFormat infers the format of a string value, returning an empty string when no format is recognised
+func detectFormat(value interface{}) string {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return ""
+	}
+
+	for _, format := range detectedFormats {
+		if !formatValidators[format](s) {
+			continue
+		}
+
+		// Narrow down the formats that would otherwise match too many plain strings
+		switch format {
+		case "duration", "quantity":
+			// Plain numbers are only durations or quantities when they carry a unit
+			if strings.Trim(s, "+-.0123456789") == "" {
+				continue
+			}
+		case "uri":
+			if u, _ := url.Parse(s); u.Host == "" {
+				continue
+			}
+		case "base64":
+			if !isLikelyBase64(s) {
+				continue
+			}
+		}
+
+		return format
+	}
+
+	return ""
+}
+
+// isLikelyBase64 requires padding, base64-only symbols, or a mix of digits and both letter cases, so that ordinary
+// words with a length divisible by four are not reported as encoded data
+func isLikelyBase64(s string) bool {
+	if len(s) < 8 || len(s)%4 != 0 {
+		return false
+	}
+
+	if strings.HasSuffix(s, "=") || strings.ContainsAny(s, "+/") {
+		return true
+	}
+
+	return strings.ContainsAny(s, "0123456789") && strings.ContainsAny(s, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") &&
+		strings.ContainsAny(s, "abcdefghijklmnopqrstuvwxyz")
+}
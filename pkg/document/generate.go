@@ -70,6 +70,10 @@ func PrintDocumentation(chartDocumentationInfo config.DocumentationInfo, templat
 	if err != nil {
 		log.Warnf("Error generating documentation file for chart: %s", err)
 	}
+
+	if viper.GetString("env-file") != "" {
+		printEnvExample(chartTemplateDataObject.Values, chartDocumentationInfo.ConfigPath, dryRun)
+	}
 }
 
 func applyMarkDownFormat(output bytes.Buffer) bytes.Buffer {
@@ -0,0 +1,140 @@
+package document
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	"github.com/blakyaks/yaml-docs/pkg/util"
+)
+
+const (
+	TagRevisions    = "tags"
+	CommitRevisions = "commits"
+
+	// UnreleasedVersion labels changes in the working copy that are not part of any revision yet
+	UnreleasedVersion = "Unreleased"
+)
+
+// ConfigRevision is a configuration file parsed at a single revision of its history
+type ConfigRevision struct {
+	Version string
+	Info    config.DocumentationInfo
+}
+
+// ConfigHistoryEntry holds the configuration changes introduced by a single version
+type ConfigHistoryEntry struct {
+	Version string     `json:"version"`
+	Changes ValuesDiff `json:"changes"`
+}
+
+// LoadConfigRevisions parses the config file at each git tag or commit in which it exists, oldest first. When the
+// working copy differs from the last revision it is appended as the unreleased version.
+func LoadConfigRevisions(configFile string, revisionMode string) ([]ConfigRevision, error) {
+	var refs []string
+	var err error
+
+	switch revisionMode {
+	case TagRevisions:
+		refs, err = util.ListGitTags(configFile)
+	case CommitRevisions:
+		refs, err = util.ListGitFileCommits(configFile)
+	default:
+		return nil, fmt.Errorf("invalid revision mode %s, must be one of (%s, %s)", revisionMode, TagRevisions, CommitRevisions)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to list git revisions: %w", err)
+	}
+
+	revisions := make([]ConfigRevision, 0, len(refs)+1)
+	var lastContents string
+
+	for _, ref := range refs {
+		contents, err := util.GetGitFileContents(ref, configFile)
+		if err != nil {
+			log.Debugf("Skipping revision %s: %s", ref, err)
+			continue
+		}
+
+		info, err := config.ParseConfigContent(fmt.Sprintf("%s:%s", ref, configFile), contents, config.DocumentationParsingConfig{})
+		if err != nil {
+			log.Warnf("Skipping revision %s, the config file could not be parsed: %s", ref, err)
+			continue
+		}
+
+		revisions = append(revisions, ConfigRevision{Version: ref, Info: info})
+		lastContents = string(contents)
+	}
+
+	contents, err := os.ReadFile(configFile)
+	if err == nil && string(contents) != lastContents {
+		info, err := config.ParseConfigContent(configFile, contents, config.DocumentationParsingConfig{})
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, ConfigRevision{Version: UnreleasedVersion, Info: info})
+	}
+
+	return revisions, nil
+}
+
+// BuildConfigHistory compares each revision with its predecessor, the first revision reports every value as added
+func BuildConfigHistory(revisions []ConfigRevision) ([]ConfigHistoryEntry, error) {
+	history := make([]ConfigHistoryEntry, 0, len(revisions))
+	previous := make([]ValueInfo, 0)
+
+	for _, revision := range revisions {
+		values, err := ListValues(revision.Info, ValueFilter{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list values for %s: %w", revision.Version, err)
+		}
+
+		changes := diffValueInfos(previous, values)
+		if !changes.IsEmpty() {
+			history = append(history, ConfigHistoryEntry{Version: revision.Version, Changes: changes})
+		}
+		previous = values
+	}
+
+	return history, nil
+}
+
+// GetSinceVersions returns the version in which each key present at the end of the history was introduced. Renamed
+// keys keep the version of the key they were renamed from.
+func GetSinceVersions(history []ConfigHistoryEntry) map[string]string {
+	sinceVersions := make(map[string]string)
+
+	for _, entry := range history {
+		for _, rename := range entry.Changes.Renamed {
+			if since, ok := sinceVersions[rename.From]; ok {
+				sinceVersions[rename.To] = since
+				delete(sinceVersions, rename.From)
+			}
+		}
+		for _, removed := range entry.Changes.Removed {
+			delete(sinceVersions, removed.Key)
+		}
+		for _, added := range entry.Changes.Added {
+			sinceVersions[added.Key] = entry.Version
+		}
+	}
+
+	return sinceVersions
+}
+
+// ConfigChangelogMarkdown renders the history as a changelog with the newest version first
+func ConfigChangelogMarkdown(history []ConfigHistoryEntry) string {
+	s := strings.Builder{}
+	s.WriteString("# Configuration Changelog\n")
+
+	for i := len(history) - 1; i >= 0; i-- {
+		s.WriteString(fmt.Sprintf("\n## %s\n", history[i].Version))
+		history[i].Changes.writeMarkdownTables(&s)
+	}
+
+	return s.String()
+}
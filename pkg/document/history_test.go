@@ -0,0 +1,56 @@
+package document
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildConfigHistory(t *testing.T) {
+	revisions := []ConfigRevision{
+		{Version: "v1.0.0", Info: getDiffTestInfo(`
+# -- Replicas
+replicas: 1
+# -- Service type
+service:
+  type: ClusterIP
+`)},
+		{Version: "v1.1.0", Info: getDiffTestInfo(`
+# -- Replicas
+replicas: 1
+# -- Service type
+service:
+  type: ClusterIP
+`)},
+		{Version: "v1.2.0", Info: getDiffTestInfo(`
+# -- Replicas
+replicas: 2
+# -- Service type
+server:
+  type: ClusterIP
+# -- Metrics toggle
+metrics: false
+`)},
+	}
+
+	history, err := BuildConfigHistory(revisions)
+
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, "v1.0.0", history[0].Version)
+	assert.Equal(t, []string{"replicas", "service"}, valueInfoKeys(history[0].Changes.Added))
+	assert.Equal(t, "v1.2.0", history[1].Version)
+	assert.Equal(t, []string{"metrics"}, valueInfoKeys(history[1].Changes.Added))
+	assert.Equal(t, []ValueRename{{From: "service", To: "server"}}, history[1].Changes.Renamed)
+
+	assert.Equal(t, map[string]string{
+		"replicas": "v1.0.0",
+		"server":   "v1.0.0",
+		"metrics":  "v1.2.0",
+	}, GetSinceVersions(history))
+
+	changelog := ConfigChangelogMarkdown(history)
+	assert.Less(t, strings.Index(changelog, "## v1.2.0"), strings.Index(changelog, "## v1.0.0"))
+}
@@ -0,0 +1,175 @@
+package document
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/spf13/viper"
+)
+
+// lintValueRows checks the documented values against their own annotations when running in strict mode
+func lintValueRows(valueRows []valueRow) error {
+	problems := make([]string, 0)
+
+	var currentVersion *semver.Version
+	if currentVersionString := viper.GetString("current-version"); currentVersionString != "" {
+		var err error
+		currentVersion, err = semver.NewVersion(currentVersionString)
+		if err != nil {
+			return fmt.Errorf("invalid current version %s: %s", currentVersionString, err)
+		}
+	}
+
+	for _, row := range valueRows {
+		if currentVersion != nil {
+			problems = append(problems, lintRemovedInVersion(row, currentVersion)...)
+		}
+		problems = append(problems, lintAllowedValues(row)...)
+		problems = append(problems, lintConstraints(row)...)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("values failing validation: \n%s", strings.Join(problems, "\n"))
+	}
+
+	return nil
+}
+
+func lintRemovedInVersion(row valueRow, currentVersion *semver.Version) []string {
+	if row.RemovedIn == "" {
+		return nil
+	}
+
+	removedIn, err := semver.NewVersion(row.RemovedIn)
+	if err != nil {
+		return []string{fmt.Sprintf("%s: invalid @removedIn version %s", row.Key, row.RemovedIn)}
+	}
+
+	if !currentVersion.LessThan(removedIn) {
+		return []string{fmt.Sprintf("%s: scheduled for removal in %s but still present in %s", row.Key, row.RemovedIn, currentVersion.Original())}
+	}
+
+	return nil
+}
+
+func lintAllowedValues(row valueRow) []string {
+	if len(row.AllowedValues) == 0 || row.value == nil {
+		return nil
+	}
+
+	values := []interface{}{row.value}
+	if list, ok := row.value.([]interface{}); ok {
+		values = list
+	}
+
+	problems := make([]string, 0)
+	for _, value := range values {
+		if !isAllowedValue(value, row.AllowedValues) {
+			problems = append(problems, fmt.Sprintf("%s: default %v is not one of the allowed values [%s]", row.Key, value, strings.Join(row.AllowedValues, ", ")))
+		}
+	}
+
+	return problems
+}
+
+func isAllowedValue(value interface{}, allowedValues []string) bool {
+	valueString := fmt.Sprint(value)
+	if value == nil {
+		valueString = "null"
+	}
+
+	for _, allowedValue := range allowedValues {
+		if valueString == allowedValue {
+			return true
+		}
+	}
+
+	return false
+}
+
+func lintConstraints(row valueRow) []string {
+	problems := make([]string, 0)
+
+	if row.Format != "" {
+		if _, ok := formatValidators[row.Format]; !ok {
+			problems = append(problems, fmt.Sprintf("%s: unknown @format %s", row.Key, row.Format))
+		}
+	}
+
+	switch value := row.value.(type) {
+	case int:
+		problems = append(problems, lintNumberConstraints(row, float64(value))...)
+	case float64:
+		problems = append(problems, lintNumberConstraints(row, value)...)
+	case string:
+		problems = append(problems, lintStringConstraints(row, value)...)
+	}
+
+	return problems
+}
+
+func lintNumberConstraints(row valueRow, value float64) []string {
+	problems := make([]string, 0)
+
+	if row.Minimum != "" {
+		minimum, err := strconv.ParseFloat(row.Minimum, 64)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid @minimum %s", row.Key, row.Minimum))
+		} else if value < minimum {
+			problems = append(problems, fmt.Sprintf("%s: default %v is less than the minimum %s", row.Key, row.value, row.Minimum))
+		}
+	}
+
+	if row.Maximum != "" {
+		maximum, err := strconv.ParseFloat(row.Maximum, 64)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid @maximum %s", row.Key, row.Maximum))
+		} else if value > maximum {
+			problems = append(problems, fmt.Sprintf("%s: default %v is greater than the maximum %s", row.Key, row.value, row.Maximum))
+		}
+	}
+
+	return problems
+}
+
+func lintStringConstraints(row valueRow, value string) []string {
+	problems := make([]string, 0)
+	length := utf8.RuneCountInString(value)
+
+	if row.MinLength != "" {
+		minLength, err := strconv.Atoi(row.MinLength)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid @minLength %s", row.Key, row.MinLength))
+		} else if length < minLength {
+			problems = append(problems, fmt.Sprintf("%s: default %q is shorter than the minimum length %s", row.Key, value, row.MinLength))
+		}
+	}
+
+	if row.MaxLength != "" {
+		maxLength, err := strconv.Atoi(row.MaxLength)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid @maxLength %s", row.Key, row.MaxLength))
+		} else if length > maxLength {
+			problems = append(problems, fmt.Sprintf("%s: default %q is longer than the maximum length %s", row.Key, value, row.MaxLength))
+		}
+	}
+
+	if row.Pattern != "" {
+		pattern, err := regexp.Compile(row.Pattern)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid @pattern %s", row.Key, row.Pattern))
+		} else if !pattern.MatchString(value) {
+			problems = append(problems, fmt.Sprintf("%s: default %q does not match the pattern %s", row.Key, value, row.Pattern))
+		}
+	}
+
+	if validator, ok := formatValidators[row.Format]; ok && !validator(value) {
+		problems = append(problems, fmt.Sprintf("%s: default %q is not a valid %s", row.Key, value, row.Format))
+	}
+
+	return problems
+}
@@ -0,0 +1,97 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLintRemovedInVersion(t *testing.T) {
+	valueRows := []valueRow{
+		{Key: "verbose", RemovedIn: "2.0.0"},
+		{Key: "listen", RemovedIn: "3.0.0"},
+		{Key: "port"},
+	}
+
+	viper.Set("current-version", "1.9.0")
+	t.Cleanup(func() { viper.Set("current-version", "") })
+	assert.NoError(t, lintValueRows(valueRows))
+
+	viper.Set("current-version", "v2.0.0")
+	assert.EqualError(t, lintValueRows(valueRows), "values failing validation: \nverbose: scheduled for removal in 2.0.0 but still present in v2.0.0")
+}
+
+func TestLintConstraints(t *testing.T) {
+	configValues := parseYamlValues(`
+# -- Number of replicas
+# @minimum 1
+# @maximum 10
+replicas: 0
+
+# -- Release name
+# @minLength 3
+# @pattern ^[a-z-]+$
+name: Ab
+
+# -- Request timeout
+# @format duration
+timeout: 30s
+
+# -- Pod network
+# @format cidr
+podCidr: 10.0.0.0/33
+
+# -- Upstream
+# @format url
+upstream: http://example.com
+`)
+
+	valuesRows, err := getSortedValuesTableRows(configValues, make(map[string]config.ValueDescription))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"minimum `1`", "maximum `10`"}, valuesRows[2].Constraints())
+
+	assert.EqualError(t, lintValueRows(valuesRows), `values failing validation: 
+name: default "Ab" is shorter than the minimum length 3
+name: default "Ab" does not match the pattern ^[a-z-]+$
+podCidr: default "10.0.0.0/33" is not a valid cidr
+replicas: default 0 is less than the minimum 1
+upstream: unknown @format url`)
+}
+
+func TestLintWarnsOutsideStrictMode(t *testing.T) {
+	configValues := parseYamlValues(`
+# -- Service type
+# @enum [ClusterIP, NodePort]
+serviceType: Ingress
+`)
+
+	hook := test.NewGlobal()
+	t.Cleanup(hook.Reset)
+	t.Cleanup(viper.Reset)
+
+	info := config.DocumentationInfo{
+		ConfigPath:         "values.yaml",
+		Values:             &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{configValues}},
+		ValuesDescriptions: make(map[string]config.ValueDescription),
+	}
+
+	_, err := getChartTemplateData(info, "", true)
+	assert.NoError(t, err)
+
+	warnings := make([]string, 0)
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == logrus.WarnLevel {
+			warnings = append(warnings, entry.Message)
+		}
+	}
+	assert.Contains(t, warnings, "values.yaml: values failing validation: \nserviceType: default Ingress is not one of the allowed values [ClusterIP, NodePort]")
+
+	viper.Set("documentation-strict-mode", true)
+	_, err = getChartTemplateData(info, "", true)
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package document
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -26,18 +27,58 @@ type valueRow struct {
 	ExampleName            string
 	ExampleDescription     string
 	Example                string
+	Since                  string
+	RemovedIn              string
+	DeprecatedIn           string
+	Replacement            string
+	AllowedValues          []string
+	Minimum                string
+	Maximum                string
+	MinLength              string
+	MaxLength              string
+	Pattern                string
+	Format                 string
+	DetectedFormat         string
+	EnvVar                 string
+	SetFlag                string
+	SchemaConflicts        []string
 	Column                 int
 	LineNumber             int
 	Hidden                 bool
 	Required               bool
 	Deprecated             bool
 	Experimental           bool
+	Sensitive              bool
+
+	// value is the decoded default the row was built from, used to validate the row against its annotations
+	value interface{}
+}
+
+// Constraints returns a short description of each constraint annotation on the value, for use in templates. Pipes are
+// escaped, so that patterns such as a|b do not split the row of a Markdown table.
+func (r valueRow) Constraints() []string {
+	constraints := make([]string, 0)
+	for _, c := range []struct{ name, value string }{
+		{"minimum", r.Minimum},
+		{"maximum", r.Maximum},
+		{"min length", r.MinLength},
+		{"max length", r.MaxLength},
+		{"pattern", r.Pattern},
+		{"format", r.Format},
+	} {
+		if c.value != "" {
+			constraints = append(constraints, fmt.Sprintf("%s `%s`", c.name, strings.ReplaceAll(c.value, "|", `\|`)))
+		}
+	}
+
+	return constraints
 }
 
 type chartTemplateData struct {
 	config.DocumentationInfo
 	YamlDocsVersion   string
 	Values            []valueRow
+	ValueTree         valueTreeNode
 	Sections          sections
 	Files             files
 	SkipVersionFooter bool
@@ -56,6 +97,7 @@ type section struct {
 	SectionItems []valueRow
 	Examples     []example
 	SectionBreak bool
+	ValueTree    valueTreeNode
 }
 
 type example struct {
@@ -124,6 +166,9 @@ func getUnsortedValueRows(document *yaml.Node, descriptions map[string]config.Va
 
 	var allValueRows []valueRow
 
+	// Section inheritance must not carry over from a previously processed document
+	lastKnownSection, lastKnownSectionDescription = "", ""
+
 	for _, contentNode := range document.Content {
 		valueRows, err := createValueRowsFromField("", nil, contentNode, descriptions, true)
 		if err != nil {
@@ -221,18 +266,31 @@ func applyAutoSectionToValueRows(valueRows []valueRow) {
 	}
 }
 
+func applySinceVersionsToValueRows(valueRows []valueRow, sinceVersions map[string]string) {
+	for i := range valueRows {
+		if since, ok := sinceVersions[valueRows[i].Key]; ok && valueRows[i].Since == "" {
+			valueRows[i].Since = since
+		}
+	}
+}
+
 func getSortedSections(s *sections) {
 	sort.Slice(s.Sections, func(i, j int) bool {
 		return naturalLess(s.Sections[i].SectionName, s.Sections[j].SectionName)
 	})
 }
 
-func getChartTemplateData(info config.DocumentationInfo, yamlDocsVersion string, skipVersionFooter bool) (chartTemplateData, error) {
-	valuesTableRows, err := getUnsortedValueRows(info.Values, info.ValuesDescriptions)
+// getValueRows builds the sorted value rows for the documentation info, applying the configured filtering and section
+// inheritance options
+func getValueRows(info config.DocumentationInfo) ([]valueRow, error) {
+	descriptions, conflicts := applySchemaDescriptions(info)
+	valuesTableRows, err := getUnsortedValueRows(info.Values, descriptions)
 	if err != nil {
-		return chartTemplateData{}, err
+		return nil, err
 	}
 
+	valuesTableRows = filterValueRowsByPath(valuesTableRows)
+
 	if viper.GetBool("ignore-non-descriptions") {
 		valuesTableRows = removeRowsWithoutDescription(valuesTableRows)
 	}
@@ -241,13 +299,41 @@ func getChartTemplateData(info config.DocumentationInfo, yamlDocsVersion string,
 		applyAutoSectionToValueRows(valuesTableRows)
 	}
 
+	applySinceVersionsToValueRows(valuesTableRows, info.SinceVersions)
+	applyEnvVarsToValueRows(valuesTableRows, info.Values)
+	applySetFlagsToValueRows(valuesTableRows, info.Values)
+	applySchemaConflictsToValueRows(valuesTableRows, conflicts)
 	sortValueRows(valuesTableRows)
+
+	return valuesTableRows, nil
+}
+
+func getChartTemplateData(info config.DocumentationInfo, yamlDocsVersion string, skipVersionFooter bool) (chartTemplateData, error) {
+	valuesTableRows, err := getValueRows(info)
+	if err != nil {
+		return chartTemplateData{}, err
+	}
+
+	// Defaults failing their annotations fail the generation in strict mode, and are only reported otherwise
+	if err := lintValueRows(valuesTableRows); err != nil {
+		if viper.GetBool("documentation-strict-mode") {
+			return chartTemplateData{}, err
+		}
+		log.Warnf("%s: %s", info.ConfigPath, err)
+	}
+
 	valueRowsSectionSorted := getSectionedValueRows(valuesTableRows)
 	sortSectionedValueRows(valueRowsSectionSorted)
 
 	// Sort the sections
 	getSortedSections(&valueRowsSectionSorted)
 
+	// Group the values of each section by their object hierarchy for the nested layout
+	valueRowsSectionSorted.DefaultSection.ValueTree = getValueTree(info.Values, valueRowsSectionSorted.DefaultSection.SectionItems, 4)
+	for i := range valueRowsSectionSorted.Sections {
+		valueRowsSectionSorted.Sections[i].ValueTree = getValueTree(info.Values, valueRowsSectionSorted.Sections[i].SectionItems, 4)
+	}
+
 	documentHeaderFile := viper.GetString("header-file")
 	createToc := !viper.GetBool("skip-toc")
 
@@ -255,6 +341,7 @@ func getChartTemplateData(info config.DocumentationInfo, yamlDocsVersion string,
 		DocumentationInfo: info,
 		YamlDocsVersion:   yamlDocsVersion,
 		Values:            valuesTableRows,
+		ValueTree:         getValueTree(info.Values, valuesTableRows, 3),
 		Sections:          valueRowsSectionSorted,
 		SkipVersionFooter: skipVersionFooter,
 		DocumentHeader:    documentHeaderFile,
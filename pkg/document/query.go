@@ -0,0 +1,134 @@
+package document
+
+import (
+	"strings"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+)
+
+// ValueInfo is the exported summary of a documented value, used when querying values outside of templates
+type ValueInfo struct {
+	Key             string   `json:"key"`
+	Type            string   `json:"type"`
+	Default         string   `json:"default"`
+	Description     string   `json:"description"`
+	Section         string   `json:"section,omitempty"`
+	Example         string   `json:"example,omitempty"`
+	Since           string   `json:"since,omitempty"`
+	DeprecatedIn    string   `json:"deprecatedIn,omitempty"`
+	Replacement     string   `json:"replacement,omitempty"`
+	RemovedIn       string   `json:"removedIn,omitempty"`
+	AllowedValues   []string `json:"allowedValues,omitempty"`
+	Minimum         string   `json:"minimum,omitempty"`
+	Maximum         string   `json:"maximum,omitempty"`
+	MinLength       string   `json:"minLength,omitempty"`
+	MaxLength       string   `json:"maxLength,omitempty"`
+	Pattern         string   `json:"pattern,omitempty"`
+	Format          string   `json:"format,omitempty"`
+	DetectedFormat  string   `json:"detectedFormat,omitempty"`
+	EnvVar          string   `json:"envVar,omitempty"`
+	SetFlag         string   `json:"setFlag,omitempty"`
+	SchemaConflicts []string `json:"schemaConflicts,omitempty"`
+	Required        bool     `json:"required"`
+	Deprecated      bool     `json:"deprecated"`
+	Experimental    bool     `json:"experimental"`
+	Hidden          bool     `json:"hidden"`
+	Sensitive       bool     `json:"sensitive"`
+}
+
+// ValueFilter restricts the values returned by ListValues, empty fields are not applied
+type ValueFilter struct {
+	Section    string
+	Required   bool
+	Deprecated bool
+}
+
+func newValueInfo(row valueRow) ValueInfo {
+	defaultValue := row.Default
+	if defaultValue == "" {
+		defaultValue = row.AutoDefault
+	}
+
+	description := row.Description
+	if description == "" {
+		description = row.AutoDescription
+	}
+
+	return ValueInfo{
+		Key:             row.Key,
+		Type:            row.Type,
+		Default:         trimCodeSpan(defaultValue),
+		Description:     description,
+		Section:         row.Section,
+		Example:         row.Example,
+		Since:           row.Since,
+		DeprecatedIn:    row.DeprecatedIn,
+		Replacement:     row.Replacement,
+		RemovedIn:       row.RemovedIn,
+		AllowedValues:   row.AllowedValues,
+		Minimum:         row.Minimum,
+		Maximum:         row.Maximum,
+		MinLength:       row.MinLength,
+		MaxLength:       row.MaxLength,
+		Pattern:         row.Pattern,
+		Format:          row.Format,
+		DetectedFormat:  row.DetectedFormat,
+		EnvVar:          row.EnvVar,
+		SetFlag:         row.SetFlag,
+		SchemaConflicts: row.SchemaConflicts,
+		Required:        row.Required,
+		Deprecated:      row.Deprecated,
+		Experimental:    row.Experimental,
+		Hidden:          row.Hidden,
+		Sensitive:       row.Sensitive,
+	}
+}
+
+// trimCodeSpan removes the markdown code span that wraps generated default values
+func trimCodeSpan(value string) string {
+	if len(value) > 1 && strings.HasPrefix(value, "`") && strings.HasSuffix(value, "`") {
+		return value[1 : len(value)-1]
+	}
+
+	return value
+}
+
+// ListValues returns the documented values of the configuration in the configured sort order
+func ListValues(info config.DocumentationInfo, filter ValueFilter) ([]ValueInfo, error) {
+	valueRows, err := getValueRows(info)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]ValueInfo, 0, len(valueRows))
+	for _, row := range valueRows {
+		if filter.Section != "" && row.Section != filter.Section {
+			continue
+		}
+		if filter.Required && !row.Required {
+			continue
+		}
+		if filter.Deprecated && !row.Deprecated {
+			continue
+		}
+		values = append(values, newValueInfo(row))
+	}
+
+	return values, nil
+}
+
+// GetValue returns the documented value with the given key path, the boolean result is false if the key is not documented
+func GetValue(info config.DocumentationInfo, key string) (ValueInfo, bool, error) {
+	values, err := ListValues(info, ValueFilter{})
+	if err != nil {
+		return ValueInfo{}, false, err
+	}
+
+	for _, value := range values {
+		if value.Key == key {
+			return value, true, nil
+		}
+	}
+
+	return ValueInfo{}, false, nil
+}
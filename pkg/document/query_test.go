@@ -0,0 +1,65 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func getQueryTestInfo() config.DocumentationInfo {
+	configValues := parseYamlValues(`
+controller:
+  service:
+    # -- (string) @required Type of the controller service
+    # @section -- Networking
+    type: LoadBalancer
+
+  # -- @deprecated Number of replicas, use autoscaling instead
+  replicas: 2
+`)
+
+	return config.DocumentationInfo{
+		Values:             &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{configValues}},
+		ValuesDescriptions: make(map[string]config.ValueDescription),
+	}
+}
+
+func TestGetValue(t *testing.T) {
+	value, found, err := GetValue(getQueryTestInfo(), "controller.service.type")
+
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, ValueInfo{
+		Key:         "controller.service.type",
+		Type:        "string",
+		Default:     "LoadBalancer",
+		Description: "Type of the controller service",
+		Section:     "Networking",
+		EnvVar:      "CONTROLLER_SERVICE_TYPE",
+		SetFlag:     "--set-string controller.service.type=LoadBalancer",
+		Required:    true,
+	}, value)
+
+	_, found, err = GetValue(getQueryTestInfo(), "controller.service.port")
+
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestListValuesFiltered(t *testing.T) {
+	values, err := ListValues(getQueryTestInfo(), ValueFilter{Deprecated: true})
+
+	require.NoError(t, err)
+	assert.Len(t, values, 1)
+	assert.Equal(t, "controller.replicas", values[0].Key)
+	assert.Equal(t, "2", values[0].Default)
+
+	values, err = ListValues(getQueryTestInfo(), ValueFilter{Section: "Networking", Required: true})
+
+	require.NoError(t, err)
+	assert.Len(t, values, 1)
+	assert.Equal(t, "controller.service.type", values[0].Key)
+}
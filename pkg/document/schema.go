@@ -0,0 +1,143 @@
+package document
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	CommentsSchemaPrecedence = "comments"
+	SchemaSchemaPrecedence   = "schema"
+)
+
+// schemaConflict records a field that is set differently by the comments and the JSON schema of a value
+type schemaConflict struct {
+	Key     string
+	Field   string
+	Comment string
+	Schema  string
+}
+
+func (c schemaConflict) String() string {
+	return fmt.Sprintf("%s: %s is %q in comments and %q in the schema", c.Key, c.Field, c.Comment, c.Schema)
+}
+
+// applySchemaDescriptions returns the value descriptions of the documentation info merged with the descriptions found
+// in its JSON schema. Fields set by both are taken from the source selected by schema-precedence, and are reported as
+// conflicts when they differ.
+func applySchemaDescriptions(info config.DocumentationInfo) (map[string]config.ValueDescription, []schemaConflict) {
+	if info.Schema == nil {
+		return info.ValuesDescriptions, nil
+	}
+
+	schemaPrecedence := viper.GetString("schema-precedence") == SchemaSchemaPrecedence
+	descriptions := make(map[string]config.ValueDescription, len(info.ValuesDescriptions))
+	for k, v := range info.ValuesDescriptions {
+		descriptions[k] = v
+	}
+
+	conflicts := make([]schemaConflict, 0)
+	walkDocumentValueKeys(info.Values, func(key string, segments []keySegment, keyNode *yaml.Node, value *yaml.Node) {
+		schema := info.Schema
+		for _, segment := range segments {
+			if segment.IsIndex {
+				schema = schema.Item()
+			} else {
+				schema = schema.Property(segment.Key)
+			}
+			if schema == nil {
+				return
+			}
+		}
+
+		description, hasDescription := descriptions[key]
+		if !hasDescription && keyNode != nil {
+			description = getDescriptionFromNode(keyNode)
+
+			// Custom notation types are documented differently when described by comments on the node
+			if description.NotationType != "" {
+				return
+			}
+		}
+
+		// The default and type of a value are taken from the values file, unless the value is null
+		schemaDescription := schema.ValueDescription(segments[len(segments)-1].String())
+		schemaType := schemaDescription.ValueType
+		if value.Tag != "!!null" {
+			schemaDescription.Default = ""
+			schemaDescription.ValueType = ""
+
+			if description.ValueType != "" && schemaType != "" && !strings.HasPrefix(description.ValueType, schemaType) {
+				conflicts = append(conflicts, schemaConflict{Key: key, Field: "type", Comment: description.ValueType, Schema: schemaType})
+			}
+		}
+
+		merged, valueConflicts := mergeSchemaDescription(key, description, schemaDescription, schemaPrecedence)
+		conflicts = append(conflicts, valueConflicts...)
+		if merged.Description != "" {
+			descriptions[key] = merged
+		}
+	})
+
+	for _, conflict := range conflicts {
+		log.Warnf("Schema conflict for %s", conflict)
+	}
+
+	return descriptions, conflicts
+}
+
+// applySchemaConflictsToValueRows lists the conflicts between the comments and the JSON schema on the row of each value
+func applySchemaConflictsToValueRows(valueRows []valueRow, conflicts []schemaConflict) {
+	conflictsByKey := make(map[string][]string)
+	for _, conflict := range conflicts {
+		conflictsByKey[conflict.Key] = append(conflictsByKey[conflict.Key], conflict.String())
+	}
+
+	for i := range valueRows {
+		valueRows[i].SchemaConflicts = conflictsByKey[valueRows[i].Key]
+	}
+}
+
+func mergeSchemaDescription(key string, description config.ValueDescription, schema config.ValueDescription, schemaPrecedence bool) (config.ValueDescription, []schemaConflict) {
+	conflicts := make([]schemaConflict, 0)
+
+	mergeField := func(field string, comment *string, fromSchema string) {
+		if *comment != "" && fromSchema != "" && *comment != fromSchema {
+			conflicts = append(conflicts, schemaConflict{Key: key, Field: field, Comment: *comment, Schema: fromSchema})
+		}
+		if fromSchema != "" && (*comment == "" || schemaPrecedence) {
+			*comment = fromSchema
+		}
+	}
+
+	mergeField("description", &description.Description, schema.Description)
+	mergeField("type", &description.ValueType, schema.ValueType)
+	mergeField("default", &description.Default, schema.Default)
+	mergeField("example", &description.Example, schema.Example)
+	mergeField("minimum", &description.Minimum, schema.Minimum)
+	mergeField("maximum", &description.Maximum, schema.Maximum)
+	mergeField("min length", &description.MinLength, schema.MinLength)
+	mergeField("max length", &description.MaxLength, schema.MaxLength)
+	mergeField("pattern", &description.Pattern, schema.Pattern)
+	mergeField("format", &description.Format, schema.Format)
+
+	if len(schema.AllowedValues) > 0 {
+		commentValues, schemaValues := strings.Join(description.AllowedValues, ", "), strings.Join(schema.AllowedValues, ", ")
+		if commentValues != "" && commentValues != schemaValues {
+			conflicts = append(conflicts, schemaConflict{Key: key, Field: "allowed values", Comment: commentValues, Schema: schemaValues})
+		}
+		if commentValues == "" || schemaPrecedence {
+			description.AllowedValues = schema.AllowedValues
+		}
+	}
+
+	// Comments cannot mark a value as not deprecated, so a deprecated schema always applies
+	description.Deprecated = description.Deprecated || schema.Deprecated
+
+	return description, conflicts
+}
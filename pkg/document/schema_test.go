@@ -0,0 +1,86 @@
+package document
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func getSchemaTestInfo(t *testing.T) config.DocumentationInfo {
+	schemaFile := filepath.Join(t.TempDir(), "values.schema.json")
+	require.NoError(t, os.WriteFile(schemaFile, []byte(`{
+  "type": "object",
+  "properties": {
+    "replicas": {"type": "integer", "description": "Number of controller replicas"},
+    "logLevel": {"type": "string", "description": "Log level", "enum": ["debug", "info"], "deprecated": true},
+    "nodeName": {"type": ["string", "null"], "description": "Node to schedule on", "default": "node-1"}
+  }
+}`), 0644))
+
+	schema, err := config.ParseJsonSchema(schemaFile)
+	require.NoError(t, err)
+
+	// The values are split across two configuration files, as joined for a configuration directory
+	configValues := parseYamlValues(`
+# -- Number of replicas
+replicas: 1
+`)
+	moreConfigValues := parseYamlValues(`
+logLevel: info
+nodeName:
+`)
+
+	return config.DocumentationInfo{
+		Values:             &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{configValues, moreConfigValues}},
+		ValuesDescriptions: make(map[string]config.ValueDescription),
+		Schema:             schema,
+	}
+}
+
+func TestSchemaDescriptions(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("ignore-non-descriptions", true)
+
+	valueRows, err := getValueRows(getSchemaTestInfo(t))
+	require.NoError(t, err)
+	require.Len(t, valueRows, 3)
+
+	assert.Equal(t, "logLevel", valueRows[0].Key)
+	assert.Equal(t, "Log level", valueRows[0].Description)
+	assert.Equal(t, []string{"debug", "info"}, valueRows[0].AllowedValues)
+	assert.True(t, valueRows[0].Deprecated)
+
+	assert.Equal(t, "nodeName", valueRows[1].Key)
+	assert.Equal(t, "Node to schedule on", valueRows[1].Description)
+	assert.Equal(t, "string", valueRows[1].Type)
+	assert.Equal(t, "`node-1`", valueRows[1].Default)
+
+	assert.Equal(t, "replicas", valueRows[2].Key)
+	assert.Equal(t, "Number of replicas", valueRows[2].Description)
+	assert.Equal(t, []string{`replicas: description is "Number of replicas" in comments and "Number of controller replicas" in the schema`}, valueRows[2].SchemaConflicts)
+	assert.Empty(t, valueRows[0].SchemaConflicts)
+}
+
+func TestSchemaPrecedence(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	descriptions, conflicts := applySchemaDescriptions(getSchemaTestInfo(t))
+	assert.Equal(t, "Number of replicas", descriptions["replicas"].Description)
+	assert.Equal(t, []schemaConflict{{
+		Key:     "replicas",
+		Field:   "description",
+		Comment: "Number of replicas",
+		Schema:  "Number of controller replicas",
+	}}, conflicts)
+
+	viper.Set("schema-precedence", SchemaSchemaPrecedence)
+
+	descriptions, _ = applySchemaDescriptions(getSchemaTestInfo(t))
+	assert.Equal(t, "Number of controller replicas", descriptions["replicas"].Description)
+}
@@ -0,0 +1,114 @@
+package document
+
+import (
+	"regexp"
+	"slices"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+)
+
+// sensitiveKeyRegexps caches the compiled sensitive-key-regex patterns, which are checked for every value and nested
+// field, along with the patterns they were compiled from
+var sensitiveKeyRegexps = struct {
+	sync.Mutex
+	patterns []string
+	regexps  []*regexp.Regexp
+}{}
+
+// getSensitiveKeyRegexps returns the configured key path patterns whose defaults must be masked, compiling them again
+// only when the configuration changes
+func getSensitiveKeyRegexps() []*regexp.Regexp {
+	patterns := viper.GetStringSlice("sensitive-key-regex")
+
+	sensitiveKeyRegexps.Lock()
+	defer sensitiveKeyRegexps.Unlock()
+	if sensitiveKeyRegexps.regexps != nil && slices.Equal(patterns, sensitiveKeyRegexps.patterns) {
+		return sensitiveKeyRegexps.regexps
+	}
+
+	regexps := make([]*regexp.Regexp, 0)
+	for _, item := range patterns {
+		regex, err := regexp.Compile(item)
+		if err != nil {
+			log.Warnf("Invalid sensitive key regex %s: %s", item, err)
+			continue
+		}
+		regexps = append(regexps, regex)
+	}
+	sensitiveKeyRegexps.patterns, sensitiveKeyRegexps.regexps = patterns, regexps
+
+	return regexps
+}
+
+func isSensitiveKey(key string) bool {
+	for _, regex := range getSensitiveKeyRegexps() {
+		if regex.MatchString(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSensitiveValue(key string, description config.ValueDescription, autoDescription config.ValueDescription) bool {
+	return description.Sensitive || autoDescription.Sensitive || isSensitiveKey(key)
+}
+
+// withoutSensitiveTexts drops the @default and @example texts of a sensitive value's description
+func withoutSensitiveTexts(description config.ValueDescription) config.ValueDescription {
+	description.Default = ""
+	description.Example = ""
+	description.ExampleName = ""
+	description.ExampleDescription = ""
+
+	return description
+}
+
+// maskSensitiveValues masks the nested fields of an object or list default that are sensitive themselves, either
+// through their key path or an @sensitive annotation, so that they do not leak through the default of a parent
+func maskSensitiveValues(prefix string, node *yaml.Node, value interface{}, keysToDescriptions map[string]config.ValueDescription) interface{} {
+	switch node.Kind {
+	case yaml.MappingNode:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+
+		for i := 0; i < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			childKey := formatNextObjectKeyPrefix(prefix, k.Value)
+			if isSensitiveValue(childKey, keysToDescriptions[childKey], getDescriptionFromNode(k)) {
+				m[k.Value] = maskedValue
+				continue
+			}
+			m[k.Value] = maskSensitiveValues(childKey, v, m[k.Value], keysToDescriptions)
+		}
+
+		return m
+	case yaml.SequenceNode:
+		l, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+
+		for i, v := range node.Content {
+			childKey := formatNextListKeyPrefix(prefix, i)
+			if isSensitiveValue(childKey, keysToDescriptions[childKey], getDescriptionFromNode(v)) {
+				l[i] = maskedValue
+				continue
+			}
+			l[i] = maskSensitiveValues(childKey, v, l[i], keysToDescriptions)
+		}
+
+		return l
+	case yaml.AliasNode:
+		return maskSensitiveValues(prefix, node.Alias, value, keysToDescriptions)
+	}
+
+	return value
+}
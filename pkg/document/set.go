@@ -0,0 +1,63 @@
+package document
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+const setFlagPlaceholder = "<value>"
+
+// Brackets are left out as they are glob characters in bash and zsh, so list keys are always quoted
+var setFlagUnquotedRegex = regexp.MustCompile(`^[A-Za-z0-9_./:@=+-]*$`)
+var setFlagValueEscaper = strings.NewReplacer(`\`, `\\`, `,`, `\,`)
+
+// applySetFlagsToValueRows sets a Helm override flag for each value, built from its key in --set syntax and its
+// default. Strings use --set-string so they are not converted, objects and lists use --set-json.
+func applySetFlagsToValueRows(valueRows []valueRow, document *yaml.Node) {
+	helmKeys := make(map[string]string)
+	walkDocumentValueKeys(document, func(key string, segments []keySegment, _ *yaml.Node, _ *yaml.Node) {
+		helmKey := ""
+		for _, segment := range segments {
+			if segment.IsIndex {
+				helmKey = config.FormatListKey(config.HelmKeyNotation, helmKey, segment.Index)
+			} else {
+				helmKey = config.FormatObjectKey(config.HelmKeyNotation, helmKey, segment.Key)
+			}
+		}
+		helmKeys[key] = helmKey
+	})
+
+	for i := range valueRows {
+		if helmKey, ok := helmKeys[valueRows[i].Key]; ok {
+			valueRows[i].SetFlag = getSetFlag(helmKey, valueRows[i])
+		}
+	}
+}
+
+func getSetFlag(helmKey string, row valueRow) string {
+	flag, value := "--set", setFlagPlaceholder
+
+	switch v := row.value.(type) {
+	case nil:
+	case string:
+		flag, value = "--set-string", setFlagValueEscaper.Replace(v)
+	case map[string]interface{}, []interface{}:
+		jsonValue, err := jsonMarshalNoEscape(row.Key, v)
+		if err == nil {
+			flag, value = "--set-json", jsonValue
+		}
+	default:
+		value = fmt.Sprint(v)
+	}
+
+	argument := fmt.Sprintf("%s=%s", helmKey, value)
+	if !setFlagUnquotedRegex.MatchString(argument) {
+		argument = "'" + strings.ReplaceAll(argument, "'", `'\''`) + "'"
+	}
+
+	return fmt.Sprintf("%s %s", flag, argument)
+}
@@ -0,0 +1,76 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSetFlags(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("key-notation", config.JsonPointerKeyNotation)
+	viper.Set("sensitive-key-regex", []string{"(?i).*password.*"})
+
+	configValues := parseYamlValues(`
+service:
+  annotations:
+    # -- Hostname for external-dns
+    external-dns.alpha.kubernetes.io/hostname: example.com
+  # -- Service port
+  port: 80
+# -- Node selector
+nodeSelector:
+  kubernetes.io/os: linux
+# -- Database password
+password: hunter2
+# -- Args passed to the container
+args: ["--verbose", "--level=debug,trace"]
+`)
+
+	valueRows, err := getValueRows(config.DocumentationInfo{
+		Values:             &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{configValues}},
+		ValuesDescriptions: make(map[string]config.ValueDescription),
+	})
+	require.NoError(t, err)
+
+	setFlags := make(map[string]string)
+	for _, row := range valueRows {
+		setFlags[row.Key] = row.SetFlag
+	}
+
+	assert.Equal(t, map[string]string{
+		"/args":         `--set-json 'args=["--verbose","--level=debug,trace"]'`,
+		"/nodeSelector": `--set-json 'nodeSelector={"kubernetes.io/os":"linux"}'`,
+		"/password":     "--set 'password=<value>'",
+		"/service/annotations/external-dns.alpha.kubernetes.io~1hostname": `--set-string 'service.annotations.external-dns\.alpha\.kubernetes\.io/hostname=example.com'`,
+		"/service/port": "--set service.port=80",
+	}, setFlags)
+}
+
+func TestSetFlagsMultipleFiles(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	valueRows, err := getValueRows(config.DocumentationInfo{
+		Values: &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{
+			parseYamlValues("# -- Set in the first file\na: 1\n"),
+			parseYamlValues("extraVolumes:\n  # -- Volume name\n  - name: config\nports:\n  # -- First port\n  - 80\n"),
+		}},
+		ValuesDescriptions: make(map[string]config.ValueDescription),
+	})
+	require.NoError(t, err)
+
+	setFlags := make(map[string]string)
+	for _, row := range valueRows {
+		setFlags[row.Key] = row.SetFlag
+	}
+
+	assert.Equal(t, map[string]string{
+		"a":               "--set a=1",
+		"extraVolumes[0]": `--set-json 'extraVolumes[0]={"name":"config"}'`,
+		"ports[0]":        "--set 'ports[0]=80'",
+	}, setFlags)
+}
@@ -0,0 +1,107 @@
+package document
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	binaryTag  = "!!binary"
+	secretTag  = "!secret"
+	envTag     = "!env"
+	includeTag = "!include"
+
+	binaryType = "binary"
+)
+
+// Display modes for the default value of scalars with a custom tag
+const (
+	RawTagDisplay  = "raw"
+	TagTagDisplay  = "tag"
+	MaskTagDisplay = "mask"
+)
+
+const maskedValue = "********"
+
+// TagHandler describes how scalars carrying a custom YAML tag are documented
+type TagHandler struct {
+	// Type is the documented type, an empty type is inferred from the untagged scalar
+	Type string
+	// Display is the default display mode of the value, one of raw, tag or mask
+	Display string
+}
+
+var tagHandlers = map[string]TagHandler{
+	binaryTag:  {Type: binaryType, Display: RawTagDisplay},
+	secretTag:  {Type: stringType, Display: MaskTagDisplay},
+	envTag:     {Type: stringType, Display: TagTagDisplay},
+	includeTag: {Type: stringType, Display: TagTagDisplay},
+}
+var tagHandlersMu = &sync.RWMutex{}
+
+// RegisterTagHandler adds or replaces the handler used to document scalars with the given tag
+func RegisterTagHandler(tag string, handler TagHandler) {
+	tagHandlersMu.Lock()
+	defer tagHandlersMu.Unlock()
+
+	tagHandlers[tag] = handler
+}
+
+func getTagHandler(tag string) TagHandler {
+	tagHandlersMu.RLock()
+	handler, ok := tagHandlers[tag]
+	tagHandlersMu.RUnlock()
+
+	if !ok {
+		handler = TagHandler{Display: TagTagDisplay}
+	}
+
+	// Display modes configured on the command line take precedence over the registered ones
+	for _, tagDisplay := range viper.GetStringSlice("tag-display") {
+		configuredTag, display, found := strings.Cut(tagDisplay, "=")
+		if !found {
+			log.Warnf("Invalid tag display %s, expected the format <tag>=<%s|%s|%s>", tagDisplay, RawTagDisplay, TagTagDisplay, MaskTagDisplay)
+			continue
+		}
+		if configuredTag == tag {
+			handler.Display = display
+		}
+	}
+
+	return handler
+}
+
+// resolveTaggedScalar returns the value to document for a scalar with a custom tag, its documented type, and whether
+// the value is the real default rather than a masked or annotated form of it
+func resolveTaggedScalar(node *yaml.Node) (interface{}, string, bool) {
+	handler := getTagHandler(node.Tag)
+
+	var value interface{} = node.Value
+	valueType := handler.Type
+
+	// Unknown tags are documented as the scalar would have been without the tag
+	if valueType == "" {
+		var untagged interface{}
+		if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) == 0 && yaml.Unmarshal([]byte(node.Value), &untagged) == nil && untagged != nil {
+			value = untagged
+		}
+		valueType = getTypeName(value)
+	}
+
+	switch handler.Display {
+	case MaskTagDisplay:
+		return maskedValue, valueType, false
+	case TagTagDisplay:
+		return fmt.Sprintf("%s %v", node.Tag, value), valueType, false
+	case RawTagDisplay:
+		return value, valueType, true
+	default:
+		log.Warnf("Invalid display mode %s for tag %s, using %s", handler.Display, node.Tag, TagTagDisplay)
+		return fmt.Sprintf("%s %v", node.Tag, value), valueType, false
+	}
+}
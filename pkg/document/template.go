@@ -1,6 +1,7 @@
 package document
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,10 +14,13 @@ import (
 )
 
 const defaultDocumentationTemplate = `{{ include .DocumentHeader }}
+{{- template "config.documentHeading" . }}
+{{- template "chart.badges" . }}
 {{- if .CreateToc }}
 {{ template "config.sectionToc" . }}
 {{- end }}
 {{ template "config.examplesSection" . }}
+{{- template "chart.requirementsSection" . }}
 {{ template "config.valuesSection" . }}
 {{- if not .SkipVersionFooter }}
 {{ template "yaml-docs.versionFooter" . }}
@@ -67,8 +71,11 @@ func getDocumentationTemplates(templateFiles []string) ([]string, error) {
 	}
 
 	return []string{
+		getDocumentHeadingTemplates(),
+		getChartTemplates(),
 		getSectionToc(),
 		getValuesTableTemplates(),
+		getValuesTreeTemplates(),
 		getYamlDocsVersionTemplates(),
 		getGlobalExamplesTemplates(),
 		documentationTemplate,
@@ -122,6 +129,130 @@ func getGlobalExamplesTemplates() string {
 	return s.String()
 }
 
+func getDocumentHeadingTemplates() string {
+	s := strings.Builder{}
+	s.WriteString(`{{ define "config.documentHeading" }}`)
+	s.WriteString("{{ with .Metadata }}")
+	s.WriteString("{{ if .Title }}\n# {{ .Title }}\n{{ end }}")
+	s.WriteString("{{ if .Description }}\n{{ .Description }}\n{{ end }}")
+	s.WriteString("{{ if or .Owner .Version }}\n")
+	s.WriteString("{{ if .Version }}**Version:** {{ .Version }}{{ end }}")
+	s.WriteString("{{ if and .Owner .Version }}\n\n{{ end }}")
+	s.WriteString("{{ if .Owner }}**Owner:** {{ .Owner }}{{ end }}\n")
+	s.WriteString("{{ end }}")
+	s.WriteString("{{ end }}")
+	s.WriteString("{{ end }}")
+
+	return s.String()
+}
+
+// getChartTemplates renders the metadata of the Helm chart next to the configuration, when there is one, as badges and
+// a table of the chart dependencies
+func getChartTemplates() string {
+	s := strings.Builder{}
+	s.WriteString(`{{ define "chart.badges" }}`)
+	s.WriteString("{{ with .Chart }}\n")
+	s.WriteString("{{ if .Deprecated }}![Deprecated](https://img.shields.io/badge/Status-Deprecated-critical?style=flat-square) {{ end }}")
+	s.WriteString(`{{ with .Version }}{{ toBadge "Version" . }} {{ end }}`)
+	s.WriteString(`{{ with .Type }}{{ toBadge "Type" . }} {{ end }}`)
+	s.WriteString(`{{ with .AppVersion }}{{ toBadge "AppVersion" . }} {{ end }}`)
+	s.WriteString("\n{{ end }}")
+	s.WriteString("{{ end }}")
+
+	s.WriteString(`{{ define "chart.requirementsHeader" }}## Requirements{{ end }}`)
+	s.WriteString(`{{ define "chart.requirementsTable" }}`)
+	s.WriteString("| Repository | Name | Version |\n")
+	s.WriteString("|------------|------|---------|\n")
+	s.WriteString("{{- range .Chart.Dependencies }}")
+	s.WriteString("\n| {{ .Repository }} | {{ .Name }}{{ with .Alias }} ({{ . }}){{ end }} | {{ .Version }} |")
+	s.WriteString("{{- end }}")
+	s.WriteString("{{ end }}")
+
+	s.WriteString(`{{ define "chart.requirementsSection" }}`)
+	s.WriteString("{{ if and .Chart (or .Chart.Dependencies .Chart.KubeVersion) }}\n")
+	s.WriteString(`{{ template "chart.requirementsHeader" . }}`)
+	s.WriteString("\n\n")
+	s.WriteString("{{ with .Chart.KubeVersion }}Kubernetes: `{{ . }}`\n\n{{ end }}")
+	s.WriteString(`{{ if .Chart.Dependencies }}{{ template "chart.requirementsTable" . }}{{ end }}`)
+	s.WriteString("\n{{ end }}")
+	s.WriteString("{{ end }}")
+
+	return s.String()
+}
+
+const (
+	EnvColumn = "env"
+	SetColumn = "set"
+)
+
+var extraColumnHeaders = map[string]string{
+	EnvColumn: "Env Var",
+	SetColumn: "Helm Override",
+}
+
+var extraColumnTemplates = map[string]string{
+	EnvColumn: "{{ with .EnvVar }}`{{ . }}`{{ end }}",
+	SetColumn: "{{ with .SetFlag }}`{{ . }}`{{ end }}",
+}
+
+func getExtraColumns() []string {
+	columns := make([]string, 0)
+	for _, column := range viper.GetStringSlice("extra-columns") {
+		if _, ok := extraColumnHeaders[column]; !ok {
+			log.Warnf("Invalid extra column provided %s, ignoring it", column)
+			continue
+		}
+		columns = append(columns, column)
+	}
+
+	return columns
+}
+
+// getValuesTableHeader returns the header and delimiter rows of a markdown values table, including any extra columns
+func getValuesTableHeader() string {
+	header := "| Key | Type | Required | Default | Description |"
+	delimiter := "|-----|------|----------|---------|-------------|"
+	for _, column := range getExtraColumns() {
+		header += " " + extraColumnHeaders[column] + " |"
+		delimiter += strings.Repeat("-", len(extraColumnHeaders[column])+2) + "|"
+	}
+
+	return header + "\n" + delimiter + "\n"
+}
+
+func getValuesLayout() string {
+	layout := viper.GetString("values-layout")
+	if layout != FlatValuesLayout && layout != NestedValuesLayout {
+		log.Warnf("Invalid values layout provided %s, defaulting to %s", layout, FlatValuesLayout)
+		return FlatValuesLayout
+	}
+
+	return layout
+}
+
+// getValuesTreeTemplates renders a value tree with a heading per parent key and a table of its leaf values, keyed by
+// their name relative to the parent.
+func getValuesTreeTemplates() string {
+	s := strings.Builder{}
+	s.WriteString(`{{ define "config.valuesTree" }}`)
+	s.WriteString("{{ with .Leaves }}")
+	s.WriteString("\n\n" + strings.TrimSuffix(getValuesTableHeader(), "\n"))
+	s.WriteString("  {{- range . }}")
+	s.WriteString("  {{- $name := .Name }}")
+	s.WriteString("  {{- with .Row }}")
+	s.WriteString("\n| {{ if .Experimental }}<span style='cursor: help;' title='Experimental'>✨</span>{{ end }}{{ if .Deprecated }}<span style='cursor: help;' title='Deprecated'>⚠️</span>{{ end }} {{ $name }} | {{ template \"config.valueTypeColumn\" . }} | {{ if .Required }}**{{ .Required }}**{{ else }}{{ .Required }}{{ end }} | {{ template \"config.valueDefaultColumn\" . }} | {{ template \"config.valueDescriptionColumn\" . }} |{{ template \"config.valueExtraColumns\" . }}")
+	s.WriteString("  {{- end }}")
+	s.WriteString("  {{- end }}")
+	s.WriteString("{{ end }}")
+	s.WriteString("{{ range .Branches }}")
+	s.WriteString("\n\n{{ .Heading }} {{ .Key }}")
+	s.WriteString("{{ with .Row }}\n\n{{ template \"config.valueDescriptionColumn\" . }}{{ end }}")
+	s.WriteString(`{{ template "config.valuesTree" . }}`)
+	s.WriteString("{{ end }}")
+	s.WriteString("{{ end }}")
+	return s.String()
+}
+
 func getYamlDocsVersionTemplates() string {
 	s := strings.Builder{}
 	s.WriteString(`{{ define "yaml-docs.version" }}{{ if .YamlDocsVersion }}{{ .YamlDocsVersion }}{{ end }}{{ end }}`)
@@ -163,9 +294,74 @@ func getSectionToc() string {
 	return s.String()
 }
 
+// getDefaultColumnTemplates renders long defaults in the markdown tables according to the
+// long-default-mode, either inline, collapsed in a details block or linked to an appendix.
+func getDefaultColumnTemplates() string {
+	mode := viper.GetString("long-default-mode")
+	threshold := viper.GetInt("long-default-threshold")
+
+	if mode != InlineLongDefaults && mode != DetailsLongDefaults && mode != AppendixLongDefaults {
+		log.Warnf("Invalid long default mode provided %s, defaulting to %s", mode, InlineLongDefaults)
+		mode = InlineLongDefaults
+	}
+	isLong := fmt.Sprintf("(isLongDefault %d (default .AutoDefault .Default))", threshold)
+
+	s := strings.Builder{}
+	s.WriteString(`{{ define "config.valueDefaultColumn" }}`)
+	switch mode {
+	case DetailsLongDefaults:
+		s.WriteString("{{ if " + isLong + " }}{{ toDetailsBlock .Type (default .AutoDefault .Default | toPrettyDefault) }}")
+		s.WriteString("{{ else }}{{ default .AutoDefault .Default }}{{ end }}")
+	case AppendixLongDefaults:
+		s.WriteString("{{ if " + isLong + " }}[See default](#{{ .Key | toDefaultAnchor }})")
+		s.WriteString("{{ else }}{{ default .AutoDefault .Default }}{{ end }}")
+	default:
+		s.WriteString("{{ if .Default }}{{ .Default }}{{ else }}{{ .AutoDefault }}{{ end }}")
+	}
+	s.WriteString("{{ end }}")
+
+	s.WriteString(`{{ define "config.defaultValuesAppendix" }}`)
+	if mode == AppendixLongDefaults {
+		s.WriteString("{{ $longDefaults := list }}")
+		s.WriteString("{{ range .Values }}{{ if and (not .Hidden) " + isLong + " }}{{ $longDefaults = append $longDefaults . }}{{ end }}{{ end }}")
+		s.WriteString("{{ if $longDefaults }}")
+		s.WriteString("\n\n## Default values\n")
+		s.WriteString("{{ range $longDefaults }}")
+		s.WriteString("\n<a id=\"{{ .Key | toDefaultAnchor }}\"></a>\n\n### {{ .Key }}\n\n")
+		s.WriteString("```{{ if regexMatch \"^`[\\\\[{]\" (default .AutoDefault .Default) }}json{{ end }}\n{{ default .AutoDefault .Default | toPrettyDefault }}\n```\n")
+		s.WriteString("{{ end }}")
+		s.WriteString("{{ end }}")
+	}
+	s.WriteString("{{ end }}")
+	return s.String()
+}
+
 func getValuesTableTemplates() string {
+	nested := getValuesLayout() == NestedValuesLayout
+
 	s := strings.Builder{}
 	s.WriteString(`{{ define "config.valuesHeader" }}## Values{{ end }}`)
+	s.WriteString(getDefaultColumnTemplates())
+	s.WriteString(`{{ define "config.valueVersionNotes" }}`)
+	s.WriteString("{{ if .Since }}<br>**Since:** {{ .Since }}{{ end }}")
+	s.WriteString("{{ if .DeprecatedIn }}<br>**Deprecated:** {{ .DeprecatedIn }}{{ if .Replacement }}, use `{{ .Replacement }}` instead{{ end }}{{ end }}")
+	s.WriteString("{{ if .RemovedIn }}<br>**Removed in:** {{ .RemovedIn }}{{ end }}")
+	s.WriteString("{{ end }}")
+	s.WriteString(`{{ define "config.valueTypeColumn" }}`)
+	s.WriteString("{{ .Type }}{{ if and .DetectedFormat (not .Format) }} ({{ .DetectedFormat }}){{ end }}")
+	s.WriteString("{{ end }}")
+	s.WriteString(`{{ define "config.valueConstraintNotes" }}`)
+	s.WriteString("{{ if .AllowedValues }}<br>**Allowed values:** {{ range $i, $v := .AllowedValues }}{{ if $i }}, {{ end }}`{{ $v }}`{{ end }}{{ end }}")
+	s.WriteString("{{ with .Constraints }}<br>**Constraints:** {{ join \", \" . }}{{ end }}")
+	s.WriteString("{{ end }}")
+	s.WriteString(`{{ define "config.valueDescriptionColumn" }}`)
+	s.WriteString(`{{ if .Description }}{{ .Description }}{{ else }}{{ .AutoDescription }}{{ end }}{{ template "config.valueConstraintNotes" . }}{{ template "config.valueVersionNotes" . }}`)
+	s.WriteString("{{ end }}")
+	s.WriteString(`{{ define "config.valueExtraColumns" }}`)
+	for _, column := range getExtraColumns() {
+		s.WriteString(" " + extraColumnTemplates[column] + " |")
+	}
+	s.WriteString("{{ end }}")
 	s.WriteString(`{{ define "config.valuesTable" }}`)
 	s.WriteString("{{ if .Sections.Sections }}")
 	s.WriteString("{{ range .Sections.Sections }}")
@@ -182,13 +378,16 @@ func getValuesTableTemplates() string {
 	s.WriteString("{{ end }}")
 	s.WriteString("{{- end }}")
 	s.WriteString("{{ if .Examples }}#### Values\n\n{{ end }}")
-	s.WriteString("| Key | Type | Required | Default | Description |\n")
-	s.WriteString("|-----|------|----------|---------|-------------|\n")
-	s.WriteString("  {{- range .SectionItems }}")
-	s.WriteString("  {{- if not .Hidden }}")
-	s.WriteString("\n| {{ if .Experimental }}<span style='cursor: help;' title='Experimental'>✨</span>{{ end }}{{ if .Deprecated }}<span style='cursor: help;' title='Deprecated'>⚠️</span>{{ end }} {{ .Key }} | {{ .Type }} | {{ if .Required }}**{{ .Required }}**{{ else }}{{ .Required }}{{ end }} | {{ if .Default }}{{ .Default }}{{ else }}{{ .AutoDefault }}{{ end }} | {{ if .Description }}{{ .Description }}{{ else }}{{ .AutoDescription }}{{ end }} |")
-	s.WriteString("  {{- end }}")
-	s.WriteString("  {{- end }}")
+	if nested {
+		s.WriteString(`{{ template "config.valuesTree" .ValueTree }}`)
+	} else {
+		s.WriteString(getValuesTableHeader())
+		s.WriteString("  {{- range .SectionItems }}")
+		s.WriteString("  {{- if not .Hidden }}")
+		s.WriteString("\n| {{ if .Experimental }}<span style='cursor: help;' title='Experimental'>✨</span>{{ end }}{{ if .Deprecated }}<span style='cursor: help;' title='Deprecated'>⚠️</span>{{ end }} {{ .Key }} | {{ template \"config.valueTypeColumn\" . }} | {{ if .Required }}**{{ .Required }}**{{ else }}{{ .Required }}{{ end }} | {{ template \"config.valueDefaultColumn\" . }} | {{ template \"config.valueDescriptionColumn\" . }} |{{ template \"config.valueExtraColumns\" . }}")
+		s.WriteString("  {{- end }}")
+		s.WriteString("  {{- end }}")
+	}
 	s.WriteString("{{ if .SectionBreak }}\n\n<div style=\"page-break-after: always;\"></div>{{ end }}")
 	s.WriteString("{{- end }}")
 
@@ -197,20 +396,26 @@ func getValuesTableTemplates() string {
 	s.WriteString("\n\n")
 	s.WriteString("\n### {{ .Sections.DefaultSection.SectionName }}\n")
 	s.WriteString("\n")
-	s.WriteString("| Key | Type | Required | Default | Description |\n")
-	s.WriteString("|-----|------|----------|---------|-------------|\n")
-	s.WriteString("  {{- range .Sections.DefaultSection.SectionItems }}")
-	s.WriteString("  {{- if not .Hidden }}")
-	s.WriteString("\n| {{ if .Experimental }}<span style='cursor: help;' title='Experimental'>✨</span>{{ end }}{{ if .Deprecated }}<span style='cursor: help;' title='Deprecated'>⚠️</span>{{ end }} {{ .Key }} | {{ .Type }} | {{ if .Required }}**{{ .Required }}**{{ else }}{{ .Required }}{{ end }} | {{ if .Default }}{{ .Default }}{{ else }}{{ .AutoDefault }}{{ end }} | {{ if .Description }}{{ .Description }}{{ else }}{{ .AutoDescription }}{{ end }} |")
-	s.WriteString("  {{- end }}")
-	s.WriteString("  {{- end }}")
+	if nested {
+		s.WriteString(`{{ template "config.valuesTree" .Sections.DefaultSection.ValueTree }}`)
+	} else {
+		s.WriteString(getValuesTableHeader())
+		s.WriteString("  {{- range .Sections.DefaultSection.SectionItems }}")
+		s.WriteString("  {{- if not .Hidden }}")
+		s.WriteString("\n| {{ if .Experimental }}<span style='cursor: help;' title='Experimental'>✨</span>{{ end }}{{ if .Deprecated }}<span style='cursor: help;' title='Deprecated'>⚠️</span>{{ end }} {{ .Key }} | {{ template \"config.valueTypeColumn\" . }} | {{ if .Required }}**{{ .Required }}**{{ else }}{{ .Required }}{{ end }} | {{ template \"config.valueDefaultColumn\" . }} | {{ template \"config.valueDescriptionColumn\" . }} |{{ template \"config.valueExtraColumns\" . }}")
+		s.WriteString("  {{- end }}")
+		s.WriteString("  {{- end }}")
+	}
 	s.WriteString("{{ end }}")
 	s.WriteString("{{ else }}")
-	s.WriteString("| Key | Type | Required | Default | Description |\n")
-	s.WriteString("|-----|------|----------|---------|-------------|\n")
-	s.WriteString("  {{- range .Values }}")
-	s.WriteString("\n| {{ if .Experimental }}<span style='cursor: help;' title='Experimental'>✨</span>{{ end }}{{ if .Deprecated }}<span style='cursor: help;' title='Deprecated'>⚠️</span>{{ end }} {{ .Key }} | {{ .Type }} | {{ if .Required }}**{{ .Required }}**{{ else }}{{ .Required }}{{ end }} | {{ if .Default }}{{ .Default }}{{ else }}{{ .AutoDefault }}{{ end }} | {{ if .Description }}{{ .Description }}{{ else }}{{ .AutoDescription }}{{ end }} |")
-	s.WriteString("  {{- end }}")
+	if nested {
+		s.WriteString(`{{ template "config.valuesTree" .ValueTree }}`)
+	} else {
+		s.WriteString(getValuesTableHeader())
+		s.WriteString("  {{- range .Values }}")
+		s.WriteString("\n| {{ if .Experimental }}<span style='cursor: help;' title='Experimental'>✨</span>{{ end }}{{ if .Deprecated }}<span style='cursor: help;' title='Deprecated'>⚠️</span>{{ end }} {{ .Key }} | {{ template \"config.valueTypeColumn\" . }} | {{ if .Required }}**{{ .Required }}**{{ else }}{{ .Required }}{{ end }} | {{ template \"config.valueDefaultColumn\" . }} | {{ template \"config.valueDescriptionColumn\" . }} |{{ template \"config.valueExtraColumns\" . }}")
+		s.WriteString("  {{- end }}")
+	}
 	s.WriteString("{{ end }}")
 	s.WriteString("{{ end }}")
 	s.WriteString(`{{ template "config.examplesHeader" . }}`)
@@ -222,6 +427,7 @@ func getValuesTableTemplates() string {
 	s.WriteString("{{ if .Values }}")
 	s.WriteString("\n\n")
 	s.WriteString(`{{ template "config.valuesTable" . }}`)
+	s.WriteString(`{{ template "config.defaultValuesAppendix" . }}`)
 	s.WriteString("{{ end }}")
 	s.WriteString("{{- end }}")
 
@@ -260,10 +466,10 @@ func getValuesTableTemplates() string {
 	{{- range .SectionItems }}
 		<tr>
 			<td>{{ .Key }}</td>
-			<td>{{ .Type }}</td>
+			<td>{{ template "config.valueTypeColumn" . }}</td>
 			<td>{{ .Required }}</td>
 			<td>{{ template "config.valueDefaultColumnRender" . }}</td>
-			<td>{{ if .Description }}{{ .Description }}{{ else }}{{ .AutoDescription }}{{ end }}</td>
+			<td>{{ template "config.valueDescriptionColumn" . }}</td>
 		</tr>
 	{{- end }}
 	</tbody>
@@ -283,10 +489,10 @@ func getValuesTableTemplates() string {
 	{{- range .Sections.DefaultSection.SectionItems }}
 	<tr>
 		<td>{{ .Key }}</td>
-		<td>{{ .Type }}</td>
+		<td>{{ template "config.valueTypeColumn" . }}</td>
 		<td>{{ .Required }}</td>
 		<td>{{ template "config.valueDefaultColumnRender" . }}</td>
-		<td>{{ if .Description }}{{ .Description }}{{ else }}{{ .AutoDescription }}{{ end }}</td>
+		<td>{{ template "config.valueDescriptionColumn" . }}</td>
 	</tr>
 	{{- end }}
 	</tbody>
@@ -305,10 +511,10 @@ func getValuesTableTemplates() string {
 	{{- range .Values }}
 		<tr>
 			<td>{{ .Key }}</td>
-			<td>{{ .Type }}</td>
+			<td>{{ template "config.valueTypeColumn" . }}</td>
 			<td>{{ .Required }}</td>
 			<td>{{ template "config.valueDefaultColumnRender" . }}</td>
-			<td>{{ if .Description }}{{ .Description }}{{ else }}{{ .AutoDescription }}{{ end }}</td>
+			<td>{{ template "config.valueDescriptionColumn" . }}</td>
 		</tr>
 	{{- end }}
 	</tbody>
@@ -1,8 +1,11 @@
 package document
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -26,3 +29,79 @@ func TestGetDocumentationTemplate_LoadDefaultOnNotFound(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, expected, tpl)
 }
+
+func TestLongDefaultModes(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("long-default-threshold", 20)
+
+	shortRow := valueRow{Key: "replicas", Type: "int", Default: "`1`"}
+	longRow := valueRow{Key: "resources.limits", Type: "object", Default: "`{\"cpu\":\"500m\",\"memory\":\"512Mi\"}`"}
+	data := chartTemplateData{Values: []valueRow{longRow, shortRow}}
+
+	render := func(name string, data interface{}) string {
+		tpl, err := newChartDocumentationTemplate([]string{"testdata/nonexistent.md.gotmpl"})
+		require.NoError(t, err)
+
+		var output bytes.Buffer
+		require.NoError(t, tpl.ExecuteTemplate(&output, name, data))
+		return output.String()
+	}
+
+	viper.Set("long-default-mode", InlineLongDefaults)
+	assert.Equal(t, longRow.Default, render("config.valueDefaultColumn", longRow))
+	assert.Empty(t, render("config.defaultValuesAppendix", data))
+
+	viper.Set("long-default-mode", DetailsLongDefaults)
+	assert.Equal(t, shortRow.Default, render("config.valueDefaultColumn", shortRow))
+	assert.Equal(t, "<details><summary>object</summary><pre>{<br>  &#34;cpu&#34;: &#34;500m&#34;,<br>  &#34;memory&#34;: &#34;512Mi&#34;<br>}</pre></details>", render("config.valueDefaultColumn", longRow))
+
+	viper.Set("long-default-mode", AppendixLongDefaults)
+	assert.Equal(t, shortRow.Default, render("config.valueDefaultColumn", shortRow))
+	assert.Equal(t, "[See default](#default-resources-limits-943391dc)", render("config.valueDefaultColumn", longRow))
+
+	appendix := render("config.defaultValuesAppendix", data)
+	assert.Contains(t, appendix, "## Default values")
+	assert.Contains(t, appendix, "<a id=\"default-resources-limits-943391dc\"></a>\n\n### resources.limits\n\n```json\n{\n  \"cpu\": \"500m\",\n  \"memory\": \"512Mi\"\n}\n```")
+	assert.NotContains(t, appendix, "replicas")
+}
+
+func TestConstraintNotesEscapePipes(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	tpl, err := newChartDocumentationTemplate([]string{"testdata/nonexistent.md.gotmpl"})
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+	row := valueRow{Key: "protocol", Type: "string", Pattern: "^(tcp|udp)$", MaxLength: "3"}
+	require.NoError(t, tpl.ExecuteTemplate(&output, "config.valueConstraintNotes", row))
+	assert.Equal(t, "<br>**Constraints:** max length `3`, pattern `^(tcp\\|udp)$`", output.String())
+}
+
+func TestChartTemplates(t *testing.T) {
+	render := func(name string, data interface{}) string {
+		tpl, err := newChartDocumentationTemplate([]string{"testdata/nonexistent.md.gotmpl"})
+		require.NoError(t, err)
+
+		var output bytes.Buffer
+		require.NoError(t, tpl.ExecuteTemplate(&output, name, data))
+		return output.String()
+	}
+
+	assert.Empty(t, render("chart.badges", chartTemplateData{}))
+	assert.Empty(t, render("chart.requirementsSection", chartTemplateData{}))
+
+	data := chartTemplateData{DocumentationInfo: config.DocumentationInfo{Chart: &config.ChartMetadata{
+		Version:     "1.4.0-rc_1",
+		AppVersion:  "2.3.0",
+		KubeVersion: ">=1.25.0",
+		Dependencies: []config.ChartDependency{
+			{Name: "postgresql", Alias: "db", Version: "12.1.0", Repository: "https://charts.bitnami.com/bitnami"},
+		},
+	}}}
+
+	assert.Equal(t, "\n![Version: 1.4.0-rc_1](https://img.shields.io/badge/Version-1.4.0--rc__1-informational?style=flat-square) ![AppVersion: 2.3.0](https://img.shields.io/badge/AppVersion-2.3.0-informational?style=flat-square) \n", render("chart.badges", data))
+
+	requirements := render("chart.requirementsSection", data)
+	assert.Contains(t, requirements, "## Requirements\n\nKubernetes: `>=1.25.0`\n\n")
+	assert.Contains(t, requirements, "| https://charts.bitnami.com/bitnami | postgresql (db) | 12.1.0 |")
+}
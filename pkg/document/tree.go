@@ -0,0 +1,122 @@
+package document
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/blakyaks/yaml-docs/pkg/util"
+)
+
+const (
+	FlatValuesLayout   = "flat"
+	NestedValuesLayout = "nested"
+)
+
+// valueTreeNode groups the documented values by their object hierarchy in the configuration file, so that templates
+// can render a heading per parent key rather than a single table of dotted key paths.
+type valueTreeNode struct {
+	Name     string
+	Key      string
+	Heading  string
+	Row      *valueRow
+	Children []valueTreeNode
+}
+
+// Leaves returns the documented children of the node that have no documented children of their own.
+func (n valueTreeNode) Leaves() []valueTreeNode {
+	leaves := make([]valueTreeNode, 0)
+	for _, child := range n.Children {
+		if len(child.Children) == 0 && child.Row != nil {
+			leaves = append(leaves, child)
+		}
+	}
+
+	return leaves
+}
+
+// Branches returns the children of the node that have documented children of their own.
+func (n valueTreeNode) Branches() []valueTreeNode {
+	branches := make([]valueTreeNode, 0)
+	for _, child := range n.Children {
+		if len(child.Children) > 0 {
+			branches = append(branches, child)
+		}
+	}
+
+	return branches
+}
+
+// getValueTree walks the configuration document and returns the tree of keys leading to the given value rows. Hidden
+// rows are left out, and headingLevel is the markdown heading level used for the top-level branches.
+func getValueTree(document *yaml.Node, valueRows []valueRow, headingLevel int) valueTreeNode {
+	rowsByKey := make(map[string]*valueRow)
+	for i := range valueRows {
+		if !valueRows[i].Hidden {
+			rowsByKey[valueRows[i].Key] = &valueRows[i]
+		}
+	}
+
+	root := valueTreeNode{Heading: getHeading(headingLevel - 1)}
+	if document == nil || document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return root
+	}
+
+	// Each configuration file is a mapping of its own, which are merged so that keys set by several files are listed
+	// once with every key set below them
+	if len(document.Content) > 1 {
+		document = util.MergeYAMLNodes(document.Content...)
+	}
+
+	root.Children = getValueTreeChildren("", document.Content[0], rowsByKey, headingLevel)
+	return root
+}
+
+func getValueTreeChildren(prefix string, node *yaml.Node, rowsByKey map[string]*valueRow, headingLevel int) []valueTreeNode {
+	children := make([]valueTreeNode, 0)
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name := node.Content[i].Value
+			key := formatNextObjectKeyPrefix(prefix, name)
+			if child, ok := newValueTreeNode(name, key, node.Content[i+1], rowsByKey, headingLevel); ok {
+				children = append(children, child)
+			}
+		}
+
+		if viper.GetString("sort-values-order") != FileSortOrder {
+			sort.SliceStable(children, func(i, j int) bool {
+				return children[i].Name < children[j].Name
+			})
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			key := formatNextListKeyPrefix(prefix, i)
+			if child, ok := newValueTreeNode(fmt.Sprintf("[%d]", i), key, item, rowsByKey, headingLevel); ok {
+				children = append(children, child)
+			}
+		}
+	}
+
+	return children
+}
+
+func newValueTreeNode(name string, key string, node *yaml.Node, rowsByKey map[string]*valueRow, headingLevel int) (valueTreeNode, bool) {
+	treeNode := valueTreeNode{
+		Name:     name,
+		Key:      key,
+		Heading:  getHeading(headingLevel),
+		Row:      rowsByKey[key],
+		Children: getValueTreeChildren(key, node, rowsByKey, headingLevel+1),
+	}
+
+	return treeNode, treeNode.Row != nil || len(treeNode.Children) > 0
+}
+
+func getHeading(level int) string {
+	return strings.Repeat("#", max(1, min(level, 6)))
+}
@@ -0,0 +1,81 @@
+package document
+
+import (
+	"testing"
+
+	"github.com/blakyaks/yaml-docs/pkg/config"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValueTree(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	viper.Set("sort-values-order", FileSortOrder)
+
+	configValues := parseYamlValues(`
+# -- Number of replicas
+replicas: 1
+controller:
+  # -- Controller name
+  name: ctl
+  service:
+    # -- Service type
+    type: ClusterIP
+    # -- @hidden Service port
+    port: 80
+  undocumented:
+    enabled: true
+`)
+	document := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{configValues}}
+	viper.Set("ignore-non-descriptions", true)
+	valueRows, err := getValueRows(config.DocumentationInfo{Values: document, ValuesDescriptions: map[string]config.ValueDescription{}})
+	require.NoError(t, err)
+
+	tree := getValueTree(document, valueRows, 3)
+
+	require.Len(t, tree.Leaves(), 1)
+	assert.Equal(t, "replicas", tree.Leaves()[0].Name)
+	assert.Equal(t, "Number of replicas", tree.Leaves()[0].Row.AutoDescription)
+
+	require.Len(t, tree.Branches(), 1)
+	controller := tree.Branches()[0]
+	assert.Equal(t, "controller", controller.Key)
+	assert.Equal(t, "###", controller.Heading)
+	assert.Nil(t, controller.Row)
+
+	require.Len(t, controller.Leaves(), 1)
+	assert.Equal(t, "name", controller.Leaves()[0].Name)
+
+	require.Len(t, controller.Branches(), 1)
+	service := controller.Branches()[0]
+	assert.Equal(t, "controller.service", service.Key)
+	assert.Equal(t, "####", service.Heading)
+
+	require.Len(t, service.Children, 1)
+	assert.Equal(t, "type", service.Children[0].Name)
+	assert.Equal(t, "controller.service.type", service.Children[0].Row.Key)
+
+	// Keys set by several configuration files list the keys below them from every file
+	document = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{
+		parseYamlValues("# -- Number of replicas\nreplicas: 1\na:\n  # -- Set in the first file\n  x: 1\n"),
+		parseYamlValues("a:\n  # -- Set in the second file\n  y: 2\n# -- Only in the second file\nb: true\n"),
+	}}
+	valueRows, err = getValueRows(config.DocumentationInfo{Values: document, ValuesDescriptions: map[string]config.ValueDescription{}})
+	require.NoError(t, err)
+
+	tree = getValueTree(document, valueRows, 3)
+
+	require.Len(t, tree.Leaves(), 2)
+	assert.Equal(t, "replicas", tree.Leaves()[0].Name)
+	assert.Equal(t, "b", tree.Leaves()[1].Name)
+
+	require.Len(t, tree.Branches(), 1)
+	a := tree.Branches()[0]
+	assert.Equal(t, "a", a.Key)
+	require.Len(t, a.Children, 2)
+	assert.Equal(t, "a.x", a.Children[0].Row.Key)
+	assert.Equal(t, "a.y", a.Children[1].Row.Key)
+}
+
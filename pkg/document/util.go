@@ -13,6 +13,12 @@ const (
 	FileSortOrder     = "file"
 )
 
+const (
+	InlineLongDefaults   = "inline"
+	DetailsLongDefaults  = "details"
+	AppendixLongDefaults = "appendix"
+)
+
 // The json library can only marshal maps with string keys, and so all of our lists and maps that go into documentation
 // must be converted to have only string keys before marshalling
 func convertConfigValuesToJsonable(values *yaml.Node) interface{} {
@@ -70,12 +76,27 @@ func convertConfigValuesToJsonable(values *yaml.Node) interface{} {
 				return 0
 			}
 			return decodedValue
+
+		default:
+			taggedValue, _, _ := resolveTaggedScalar(values)
+			return taggedValue
 		}
 	}
 
 	return nil
 }
 
+// firstNonEmpty returns the first of the values that is not an empty string
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
 // naturalLess compares two strings in a natural order.
 func naturalLess(a, b string) bool {
 	// Regular expression to extract numbers
@@ -100,3 +121,50 @@ func naturalLess(a, b string) bool {
 	// If all numbers compared are equal, fall back to lexicographical order
 	return a < b
 }
+
+// keySegment is a single object key or list index in the path to a value
+type keySegment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+func (s keySegment) String() string {
+	if s.IsIndex {
+		return strconv.Itoa(s.Index)
+	}
+
+	return s.Key
+}
+
+// walkDocumentValueKeys calls walkValueKeys for the mapping of every configuration file joined in the document
+func walkDocumentValueKeys(document *yaml.Node, visit func(key string, segments []keySegment, keyNode *yaml.Node, value *yaml.Node)) {
+	if document == nil || document.Kind != yaml.DocumentNode {
+		return
+	}
+
+	for _, node := range document.Content {
+		walkValueKeys("", nil, node, visit)
+	}
+}
+
+// walkValueKeys calls visit for every value below node with its key, in the configured key notation, the segments of
+// its path, its key node, which is nil for list items, and its value node
+func walkValueKeys(prefix string, segments []keySegment, node *yaml.Node, visit func(key string, segments []keySegment, keyNode *yaml.Node, value *yaml.Node)) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := formatNextObjectKeyPrefix(prefix, node.Content[i].Value)
+			keySegments := append(append([]keySegment{}, segments...), keySegment{Key: node.Content[i].Value})
+			visit(key, keySegments, node.Content[i], node.Content[i+1])
+			walkValueKeys(key, keySegments, node.Content[i+1], visit)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			key := formatNextListKeyPrefix(prefix, i)
+			keySegments := append(append([]keySegment{}, segments...), keySegment{Index: i, IsIndex: true})
+			visit(key, keySegments, nil, item)
+			walkValueKeys(key, keySegments, item, visit)
+		}
+	}
+}
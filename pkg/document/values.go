@@ -8,6 +8,7 @@ import (
 
 	"github.com/blakyaks/yaml-docs/pkg/config"
 	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
 )
 
@@ -36,26 +37,11 @@ const (
 )
 
 func formatNextListKeyPrefix(prefix string, index int) string {
-	return fmt.Sprintf("%s[%d]", prefix, index)
+	return config.FormatListKey(viper.GetString("key-notation"), prefix, index)
 }
 
 func formatNextObjectKeyPrefix(prefix string, key string) string {
-	var escapedKey string
-	var nextPrefix string
-
-	if strings.Contains(key, ".") || strings.Contains(key, " ") {
-		escapedKey = fmt.Sprintf(`"%s"`, key)
-	} else {
-		escapedKey = key
-	}
-
-	if prefix != "" {
-		nextPrefix = fmt.Sprintf("%s.%s", prefix, escapedKey)
-	} else {
-		nextPrefix = escapedKey
-	}
-
-	return nextPrefix
+	return config.FormatObjectKey(viper.GetString("key-notation"), prefix, key)
 }
 
 func getTypeName(value interface{}) string {
@@ -69,7 +55,7 @@ func getTypeName(value interface{}) string {
 	case string:
 		return stringType
 	case []interface{}:
-		return listType
+		return getListTypeName(value.([]interface{}))
 	case map[string]interface{}:
 		return objectType
 	}
@@ -77,6 +63,26 @@ func getTypeName(value interface{}) string {
 	return ""
 }
 
+// getListTypeName names the element type of lists whose elements all share a type, e.g. "list of string"
+func getListTypeName(values []interface{}) string {
+	if len(values) == 0 {
+		return listType
+	}
+
+	elementType := getTypeName(values[0])
+	for _, v := range values[1:] {
+		if getTypeName(v) != elementType {
+			return listType
+		}
+	}
+
+	if elementType == "" {
+		return listType
+	}
+
+	return fmt.Sprintf("%s of %s", listType, elementType)
+}
+
 func parseNilValueType(key string, description config.ValueDescription, autoDescription config.ValueDescription, column int, lineNumber int) valueRow {
 	if len(description.Description) == 0 {
 		description.Description = autoDescription.Description
@@ -149,7 +155,7 @@ func parseNilValueType(key string, description config.ValueDescription, autoDesc
 
 	log.Tracef("Processed key '%s': AutoSection: '%s'", key, lastKnownSection)
 
-	return valueRow{
+	row := valueRow{
 		Key:                    key,
 		Type:                   t,
 		NotationType:           autoDescription.NotationType,
@@ -171,6 +177,9 @@ func parseNilValueType(key string, description config.ValueDescription, autoDesc
 		Deprecated:             deprecated,
 		Experimental:           experimental,
 	}
+	applyValueAnnotations(&row, description, autoDescription)
+
+	return row
 }
 
 func jsonMarshalNoEscape(key string, value interface{}) (string, error) {
@@ -221,8 +230,18 @@ func createValueRow(
 	column int,
 	lineNumber int,
 ) (valueRow, error) {
+	// Sensitive defaults are replaced before they are rendered, so they never reach any output format, and explicit
+	// default and example texts are dropped as they may hold the value as well
+	sensitive := isSensitiveValue(key, description, autoDescription)
+	if sensitive {
+		description = withoutSensitiveTexts(description)
+		autoDescription = withoutSensitiveTexts(autoDescription)
+	}
+
 	if value == nil {
-		return parseNilValueType(key, description, autoDescription, column, lineNumber), nil
+		row := parseNilValueType(key, description, autoDescription, column, lineNumber)
+		row.Sensitive = sensitive
+		return row, nil
 	}
 
 	autoDefaultValue := autoDescription.Default
@@ -238,6 +257,10 @@ func createValueRow(
 		defaultType = notationType
 	}
 
+	if sensitive {
+		value = maskedValue
+	}
+
 	if defaultValue == "" && autoDefaultValue == "" && notationType == "" {
 		jsonEncodedValue, err := jsonMarshalNoEscape(key, value)
 		if err != nil {
@@ -305,7 +328,7 @@ func createValueRow(
 
 	log.Tracef("Processed key '%s': AutoSection: '%s'", key, lastKnownSection)
 
-	return valueRow{
+	row := valueRow{
 		Key:                    key,
 		Type:                   defaultType,
 		NotationType:           notationType,
@@ -326,7 +349,37 @@ func createValueRow(
 		Required:               required,
 		Deprecated:             deprecated,
 		Experimental:           experimental,
-	}, nil
+	}
+	applyValueAnnotations(&row, description, autoDescription)
+
+	row.Sensitive = sensitive
+	if notationType == "" && !sensitive {
+		row.value = value
+		row.DetectedFormat = detectFormat(value)
+	}
+
+	return row, nil
+}
+
+// applyValueAnnotations copies the annotations that do not affect how the row itself is built, preferring the
+// explicitly keyed description over the one parsed from the comment above the key
+func applyValueAnnotations(row *valueRow, description config.ValueDescription, autoDescription config.ValueDescription) {
+	row.Since = firstNonEmpty(description.Since, autoDescription.Since)
+	row.RemovedIn = firstNonEmpty(description.RemovedIn, autoDescription.RemovedIn)
+	row.DeprecatedIn = firstNonEmpty(description.DeprecatedIn, autoDescription.DeprecatedIn)
+	row.Replacement = firstNonEmpty(description.Replacement, autoDescription.Replacement)
+
+	row.AllowedValues = description.AllowedValues
+	if len(row.AllowedValues) == 0 {
+		row.AllowedValues = autoDescription.AllowedValues
+	}
+
+	row.Minimum = firstNonEmpty(description.Minimum, autoDescription.Minimum)
+	row.Maximum = firstNonEmpty(description.Maximum, autoDescription.Maximum)
+	row.MinLength = firstNonEmpty(description.MinLength, autoDescription.MinLength)
+	row.MaxLength = firstNonEmpty(description.MaxLength, autoDescription.MaxLength)
+	row.Pattern = firstNonEmpty(description.Pattern, autoDescription.Pattern)
+	row.Format = firstNonEmpty(description.Format, autoDescription.Format)
 }
 
 func createValueRowsFromList(
@@ -359,7 +412,7 @@ func createValueRowsFromList(
 	// We have a nonempty list with a description, document it, and mark that leaf nodes underneath it should not be
 	// documented without descriptions
 	if hasDescription || (autoDescription.Description != "" && autoDescription.NotationType == "") {
-		jsonableObject := convertConfigValuesToJsonable(values)
+		jsonableObject := maskSensitiveValues(prefix, values, convertConfigValuesToJsonable(values), keysToDescriptions)
 		listRow, err := createValueRow(prefix, jsonableObject, description, autoDescription, key.Column, key.Line)
 
 		if err != nil {
@@ -449,7 +502,7 @@ func createValueRowsFromObject(
 	// We have a nonempty object with a description, document it, and mark that leaf nodes underneath it should not be
 	// documented without descriptions
 	if hasDescription || (autoDescription.Description != "" && autoDescription.NotationType == "") {
-		jsonableObject := convertConfigValuesToJsonable(values)
+		jsonableObject := maskSensitiveValues(nextPrefix, values, convertConfigValuesToJsonable(values), keysToDescriptions)
 		objectRow, err := createValueRow(nextPrefix, jsonableObject, description, autoDescription, key.Column, key.Line)
 
 		if err != nil {
@@ -575,6 +628,9 @@ func createValueRowsFromField(
 			fallthrough
 		case timestampTag:
 			leafValueRow, err := createValueRow(prefix, value.Value, description, autoDescription, key.Column, key.Line)
+			if err == nil && value.ShortTag() == timestampTag && leafValueRow.NotationType == "" && !leafValueRow.Sensitive {
+				leafValueRow.DetectedFormat = getTimestampFormat(value.Value)
+			}
 			return []valueRow{leafValueRow}, err
 		case intTag:
 			var decodedValue int
@@ -602,6 +658,20 @@ func createValueRowsFromField(
 			}
 			leafValueRow, err := createValueRow(prefix, decodedValue, description, autoDescription, key.Column, key.Line)
 			return []valueRow{leafValueRow}, err
+
+		default:
+			taggedValue, taggedType, isRealValue := resolveTaggedScalar(value)
+			if description.ValueType == "" && autoDescription.ValueType == "" {
+				description.ValueType = taggedType
+			}
+
+			leafValueRow, err := createValueRow(prefix, taggedValue, description, autoDescription, key.Column, key.Line)
+			if !isRealValue {
+				// Masked and annotated defaults must not be validated or inspected for a format
+				leafValueRow.value = nil
+				leafValueRow.DetectedFormat = ""
+			}
+			return []valueRow{leafValueRow}, err
 		}
 	}
 
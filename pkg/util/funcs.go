@@ -1,7 +1,12 @@
 package util
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"html"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -21,6 +26,11 @@ func FuncMap() template.FuncMap {
 	f["trimLead"] = trimLeadingSpace
 	f["toYamlCodeBlock"] = toYamlCodeBlock
 	f["toMarkdownLink"] = toMarkdownLink
+	f["isLongDefault"] = isLongDefault
+	f["toPrettyDefault"] = toPrettyDefault
+	f["toDetailsBlock"] = toDetailsBlock
+	f["toDefaultAnchor"] = toDefaultAnchor
+	f["toBadge"] = toBadge
 	return f
 }
 
@@ -109,3 +119,61 @@ func createAnchor(str string) string {
 	str = strings.Trim(str, "-")
 	return "#" + str
 }
+
+// Returns true when the rendered default is longer than the threshold. A threshold
+// of zero or less disables the check.
+// Use from templates using {{ if isLongDefault 80 .Default }}
+func isLongDefault(threshold int, str string) bool {
+	return threshold > 0 && len(str) > threshold
+}
+
+// Returns a default value with its code span removed, pretty printing it when
+// the value is JSON.
+// Use from templates using {{ .Default | toPrettyDefault }}
+func toPrettyDefault(str string) string {
+	str = strings.TrimSpace(str)
+	if len(str) > 1 && strings.HasPrefix(str, "`") && strings.HasSuffix(str, "`") {
+		str = str[1 : len(str)-1]
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, []byte(str), "", "  "); err != nil {
+		return str
+	}
+	return indented.String()
+}
+
+// Returns the content wrapped in a collapsed HTML details block that can be used
+// within a single markdown table cell.
+// Use from templates using {{ toDetailsBlock "Show" (.Default | toPrettyDefault) }}
+func toDetailsBlock(summary string, content string) string {
+	content = html.EscapeString(content)
+	content = strings.ReplaceAll(content, "|", "&#124;")
+	content = strings.ReplaceAll(content, "\n", "<br>")
+	return fmt.Sprintf("<details><summary>%s</summary><pre>%s</pre></details>", html.EscapeString(summary), content)
+}
+
+// Returns the HTML anchor id used for a key in the default values appendix. Keys
+// that are not already made of lowercase words joined by dashes get a short hash
+// suffix, so that keys such as a.b and a_b do not share an anchor.
+// Use from templates using {{ .Key | toDefaultAnchor }}
+func toDefaultAnchor(key string) string {
+	reg := regexp.MustCompile("[^a-z0-9]+")
+	anchor := strings.Trim(reg.ReplaceAllString(strings.ToLower(key), "-"), "-")
+	if anchor == key {
+		return "default-" + anchor
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return fmt.Sprintf("default-%s-%08x", anchor, hash.Sum32())
+}
+
+// Returns a markdown shields.io badge image for the label and value, escaping the
+// dashes, underscores and spaces that shields.io uses as separators.
+// Use from templates using {{ toBadge "Version" .Version }}
+func toBadge(label string, value string) string {
+	escape := strings.NewReplacer("-", "--", "_", "__", " ", "_")
+	return fmt.Sprintf("![%s: %s](https://img.shields.io/badge/%s-%s-informational?style=flat-square)",
+		label, value, url.PathEscape(escape.Replace(label)), url.PathEscape(escape.Replace(value)))
+}
@@ -0,0 +1,21 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToDefaultAnchor(t *testing.T) {
+	assert.Equal(t, "default-replicas", toDefaultAnchor("replicas"))
+	assert.Equal(t, "default-a-b", toDefaultAnchor("a-b"))
+
+	anchors := map[string]bool{}
+	for _, key := range []string{"a-b", "a.b", "a_b", "A.b", "a b"} {
+		anchor := toDefaultAnchor(key)
+		assert.NotContains(t, anchors, anchor, key)
+		assert.Equal(t, anchor, toDefaultAnchor(key))
+		assert.Regexp(t, "^default-a-b", anchor)
+		anchors[anchor] = true
+	}
+}
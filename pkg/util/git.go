@@ -1,7 +1,14 @@
 package util
 
 import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"path"
+	"path/filepath"
 	"strings"
 )
 
@@ -14,3 +21,148 @@ func FindGitRepositoryRoot() (string, error) {
 
 	return strings.TrimSpace(string(path)), nil
 }
+
+// findGitFileRepository returns the root of the repository holding a file, along with the path of the file relative
+// to it, so git commands run from the repository root whatever the working directory. Relative paths are resolved from
+// the current working directory, and files that no longer exist are located from their closest existing directory.
+func findGitFileRepository(path string) (string, string, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", "", err
+	}
+
+	directory := filepath.Dir(path)
+	for {
+		if info, err := os.Stat(directory); err == nil && info.IsDir() {
+			break
+		}
+		parent := filepath.Dir(directory)
+		if parent == directory {
+			break
+		}
+		directory = parent
+	}
+
+	output, err := exec.Command("git", "-C", directory, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", "", fmt.Errorf("%s is not in a git repository: %w", path, err)
+	}
+	root := strings.TrimSpace(string(output))
+
+	// The repository root is reported with symlinks resolved, e.g. /private/var rather than /var on macOS
+	if resolved, err := filepath.EvalSymlinks(directory); err == nil {
+		path = filepath.Join(resolved, strings.TrimPrefix(path, directory))
+	}
+
+	relativePath, err := filepath.Rel(root, path)
+	if err != nil {
+		return "", "", err
+	}
+
+	return root, filepath.ToSlash(relativePath), nil
+}
+
+// GetGitFileContents returns the contents of a file at the given git revision. Relative paths are resolved from the
+// current working directory rather than the repository root.
+func GetGitFileContents(ref string, path string) ([]byte, error) {
+	root, relativePath, err := findGitFileRepository(path)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := exec.Command("git", "-C", root, "show", fmt.Sprintf("%s:%s", ref, relativePath)).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s at revision %s: %w", relativePath, ref, err)
+	}
+
+	return contents, nil
+}
+
+// ExtractGitFileDirectory writes the directory holding a file, as of the given git revision, to the destination
+// directory, so that the file can be parsed along with the files next to it. The path of the file in the destination
+// directory is returned.
+func ExtractGitFileDirectory(ref string, filePath string, destination string) (string, error) {
+	root, relativePath, err := findGitFileRepository(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	var stderr bytes.Buffer
+	command := exec.Command("git", "-C", root, "archive", "--format=tar", ref, "--", path.Dir(relativePath))
+	command.Stderr = &stderr
+	archive, err := command.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s at revision %s: %s", path.Dir(relativePath), ref, strings.TrimSpace(stderr.String()))
+	}
+
+	reader := tar.NewReader(bytes.NewReader(archive))
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		target := filepath.Join(destination, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(destination)+string(filepath.Separator)) {
+			return "", fmt.Errorf("invalid path %s in revision %s", header.Name, ref)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return "", err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return "", err
+			}
+			contents, err := io.ReadAll(reader)
+			if err != nil {
+				return "", err
+			}
+			if err := os.WriteFile(target, contents, 0o644); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	extractedPath := filepath.Join(destination, filepath.FromSlash(relativePath))
+	if _, err := os.Stat(extractedPath); err != nil {
+		return "", fmt.Errorf("failed to read %s at revision %s: no such file", relativePath, ref)
+	}
+
+	return extractedPath, nil
+}
+
+// ListGitTags returns the tags of the repository holding the file ordered by version
+func ListGitTags(path string) ([]string, error) {
+	root, _, err := findGitFileRepository(path)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := exec.Command("git", "-C", root, "tag", "--sort=v:refname").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Fields(string(output)), nil
+}
+
+// ListGitFileCommits returns the abbreviated hashes of the commits that changed the file, oldest first
+func ListGitFileCommits(path string) ([]string, error) {
+	root, relativePath, err := findGitFileRepository(path)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := exec.Command("git", "-C", root, "log", "--reverse", "--format=%h", "--", relativePath).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Fields(string(output)), nil
+}
@@ -0,0 +1,78 @@
+package util
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runGit(t *testing.T, directory string, args ...string) {
+	command := exec.Command("git", append([]string{"-C", directory, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	output, err := command.CombinedOutput()
+	require.NoError(t, err, string(output))
+}
+
+func TestGitCommandsRunFromRepositoryRoot(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repository := t.TempDir()
+	configFile := filepath.Join(repository, "config", "values.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(configFile), 0o755))
+	require.NoError(t, os.WriteFile(configFile, []byte("a: 1\n"), 0o644))
+
+	runGit(t, repository, "init", "-q")
+	runGit(t, repository, "add", "-A")
+	runGit(t, repository, "commit", "-q", "-m", "initial")
+	runGit(t, repository, "tag", "v1.0.0")
+
+	// The working directory is this package, outside of the temporary repository
+	commits, err := ListGitFileCommits(configFile)
+	require.NoError(t, err)
+	assert.Len(t, commits, 1)
+
+	tags, err := ListGitTags(configFile)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v1.0.0"}, tags)
+
+	contents, err := GetGitFileContents("v1.0.0", configFile)
+	require.NoError(t, err)
+	assert.Equal(t, "a: 1\n", string(contents))
+}
+
+func TestExtractGitFileDirectory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repository := t.TempDir()
+	chartDirectory := filepath.Join(repository, "chart")
+	require.NoError(t, os.MkdirAll(filepath.Join(chartDirectory, "charts", "db"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(chartDirectory, "values.yaml"), []byte("a: 1\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(chartDirectory, "charts", "db", "values.yaml"), []byte("port: 5432\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(repository, "other.yaml"), []byte("b: 2\n"), 0o644))
+
+	runGit(t, repository, "init", "-q")
+	runGit(t, repository, "add", "-A")
+	runGit(t, repository, "commit", "-q", "-m", "initial")
+	require.NoError(t, os.WriteFile(filepath.Join(chartDirectory, "values.yaml"), []byte("a: 2\n"), 0o644))
+
+	destination := t.TempDir()
+	extractedPath, err := ExtractGitFileDirectory("HEAD", filepath.Join(chartDirectory, "values.yaml"), destination)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(destination, "chart", "values.yaml"), extractedPath)
+
+	contents, err := os.ReadFile(extractedPath)
+	require.NoError(t, err)
+	assert.Equal(t, "a: 1\n", string(contents))
+	assert.FileExists(t, filepath.Join(destination, "chart", "charts", "db", "values.yaml"))
+	assert.NoFileExists(t, filepath.Join(destination, "other.yaml"))
+
+	_, err = ExtractGitFileDirectory("HEAD", filepath.Join(chartDirectory, "missing.yaml"), t.TempDir())
+	assert.Error(t, err)
+}
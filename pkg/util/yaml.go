@@ -12,7 +12,10 @@ func MergeYAMLNodes(nodes ...*yaml.Node) *yaml.Node {
 		merged = mergeYAMLNodes(merged, node)
 	}
 
-	return merged
+	return &yaml.Node{
+		Kind:    yaml.DocumentNode,
+		Content: []*yaml.Node{merged},
+	}
 }
 
 func mergeYAMLNodes(node1, node2 *yaml.Node) *yaml.Node {
@@ -62,8 +65,5 @@ func mergeYAMLNodes(node1, node2 *yaml.Node) *yaml.Node {
 		}
 	}
 
-	return &yaml.Node{
-		Kind:    yaml.DocumentNode,
-		Content: []*yaml.Node{merged},
-	}
+	return merged
 }
@@ -0,0 +1,36 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func parseYamlDocument(t *testing.T, values string) *yaml.Node {
+	var document yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(values), &document))
+
+	return &document
+}
+
+func TestMergeYAMLNodesNestedKeys(t *testing.T) {
+	merged := MergeYAMLNodes(
+		parseYamlDocument(t, "image:\n  repository: nginx\n  tag: 1.0\n"),
+		parseYamlDocument(t, "image:\n  tag: 2.0\nreplicas: 1\n"),
+	)
+
+	require.Equal(t, yaml.DocumentNode, merged.Kind)
+	require.Len(t, merged.Content, 1)
+
+	image := merged.Content[0].Content[1]
+	assert.Equal(t, yaml.MappingNode, image.Kind)
+
+	var values map[string]interface{}
+	require.NoError(t, merged.Decode(&values))
+	assert.Equal(t, map[string]interface{}{
+		"image":    map[string]interface{}{"repository": "nginx", "tag": 2.0},
+		"replicas": 1,
+	}, values)
+}